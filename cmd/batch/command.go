@@ -0,0 +1,145 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg/batch"
+	"github.com/operator-framework/audit/pkg/catalogs"
+)
+
+// BindFlags define the flags used by the batch command
+type BindFlags struct {
+	Config     string `json:"config"`
+	OutputPath string `json:"outputPath"`
+	Manifest   string `json:"manifest"`
+	Parallel   int    `json:"parallel"`
+	WorkDir    string `json:"workDir"`
+}
+
+var flags = BindFlags{}
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "audit all catalogs defined in a config file",
+		Long: "Generates the reports of all index catalogs, tags and report kinds defined in the config file and " +
+			"outputs a manifest with the result of each audit, its reports and duration.\n\n " +
+			"**When this command is useful?** \n\n" +
+			"This command is useful when the reports of many catalogs are required to be generated at once, " +
+			"e.g. to update the testdata.",
+		PreRunE: validation,
+		RunE:    run,
+	}
+
+	cmd.Flags().StringVar(&flags.Config, "config", "",
+		"path of the YAML file with the catalogs which will be audit (e.g. catalogs.yaml)")
+	if err := cmd.MarkFlagRequired("config"); err != nil {
+		log.Fatalf("Failed to mark `config` flag for `batch` sub-command as required")
+	}
+	cmd.Flags().StringVar(&flags.OutputPath, "output-path", "",
+		"overwrites the outputPath of the config, which is the dir where the reports of each catalog "+
+			"are output in a sub-dir with its name. (default: the outputPath of the config or the current dir)")
+	cmd.Flags().StringVar(&flags.Manifest, "manifest", "",
+		"path of the file where the run manifest is written (default: manifest.json in the output path)")
+	cmd.Flags().IntVar(&flags.Parallel, "parallel", 1,
+		"number of audits which run at the same time. When it is upper than 1, the bundle images are not removed "+
+			"after each audit, since they may be used by the other audits")
+	cmd.Flags().StringVar(&flags.WorkDir, "work-dir", "",
+		"dir where the images are extracted by each audit. It is removed at the end "+
+			"(default: a temporary dir)")
+
+	return cmd
+}
+
+func validation(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(flags.Config); os.IsNotExist(err) {
+		return fmt.Errorf("invalid file path informed via the --config flag (%s) : %s ", flags.Config, err)
+	}
+	if flags.Parallel < 1 {
+		return fmt.Errorf("invalid value informed via the --parallel flag :%v. It should be at least 1",
+			flags.Parallel)
+	}
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	config, err := catalogs.LoadConfig(flags.Config)
+	if err != nil {
+		return err
+	}
+	if len(flags.OutputPath) > 0 {
+		config.OutputPath = flags.OutputPath
+	}
+	// the audits run in their working dirs, so the paths must be absolute
+	if config.OutputPath, err = filepath.Abs(config.OutputPath); err != nil {
+		return err
+	}
+	for i, c := range config.Catalogs {
+		if len(c.OutputPath) == 0 {
+			continue
+		}
+		if config.Catalogs[i].OutputPath, err = filepath.Abs(c.OutputPath); err != nil {
+			return err
+		}
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the audit-tool binary : %s", err)
+	}
+
+	workDir := flags.WorkDir
+	if len(workDir) == 0 {
+		if workDir, err = ioutil.TempDir("", "audit-batch-"); err != nil {
+			return fmt.Errorf("unable to create the work dir : %s", err)
+		}
+	}
+	if workDir, err = filepath.Abs(workDir); err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	manifest := batch.Run(batch.Options{
+		Config:   config,
+		Binary:   binary,
+		Parallel: flags.Parallel,
+		WorkDir:  workDir,
+	})
+
+	manifestPath := flags.Manifest
+	if len(manifestPath) == 0 {
+		manifestPath = filepath.Join(config.OutputPath, "manifest.json")
+	}
+	if err := os.MkdirAll(filepath.Dir(manifestPath), os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create the dir of the manifest : %s", err)
+	}
+	if err := manifest.Write(manifestPath); err != nil {
+		return fmt.Errorf("unable to write the manifest %s : %s", manifestPath, err)
+	}
+	log.Infof("Manifest written in %s", manifestPath)
+
+	if failed := manifest.Failed(); failed > 0 {
+		return fmt.Errorf("%v of %v audits failed. See the manifest %s", failed, len(manifest.Jobs), manifestPath)
+	}
+	return nil
+}
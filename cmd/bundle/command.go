@@ -0,0 +1,185 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/actions"
+	"github.com/operator-framework/audit/pkg/models"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/writers"
+)
+
+var flags = bundles.BindFlags{}
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "audit a single operator bundle image or directory",
+		Long: "Provides the report with the details of the operator bundle image or directory informed, which are " +
+			"the same checks done per bundle by the index bundles report.\n\n " +
+			"**When this report is useful?** \n\n" +
+			"This report is useful for operator authors to check their bundles before submitting them to a catalog.",
+		PreRunE: validation,
+		RunE:    run,
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	cmd.Flags().StringVar(&flags.BundleImage, "image", "",
+		"operator bundle image and tag which will be audit")
+	cmd.Flags().StringVar(&flags.BundleDir, "dir", "",
+		"path of the operator bundle directory (with the manifests and metadata) which will be audit")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output", pkg.Xls,
+		fmt.Sprintf("inform the output format(s) as a comma-separated list (e.g. json,csv,md). [Flags: %s]",
+			strings.Join(writers.Formats(), ", ")))
+	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
+		"inform the path of the directory to output the report. (Default: current directory)")
+	cmd.Flags().BoolVar(&flags.DisableScorecard, "disable-scorecard", false,
+		"if set, will disable the scorecard tests")
+	cmd.Flags().BoolVar(&flags.DisableValidators, "disable-validators", false,
+		"if set, will disable the validators tests")
+	cmd.Flags().BoolVar(&flags.CheckRelatedImages, "check-related-images", false,
+		"if set, will inspect the images referenced by the bundles to check that they exist and their architectures")
+	cmd.Flags().StringVar(&flags.RelatedImagesRegistry, "related-images-registry", "",
+		"inform the registry (e.g. localhost:5000) where the related images are mirrored to check them in it "+
+			"instead of in their registries. Only used with --check-related-images")
+	cmd.Flags().BoolVar(&flags.ServerMode, "server-mode", false,
+		"if set, the image which is downloaded will not be removed")
+	cmd.Flags().StringSliceVar(&flags.Columns, "columns", []string{},
+		"inform the columns (JSON field names) to output and their order (e.g. packageName,bundleName,sdkVersion). "+
+			"(Default: all columns)")
+
+	return cmd
+}
+
+func validation(cmd *cobra.Command, args []string) error {
+	if len(flags.BundleImage) == 0 && len(flags.BundleDir) == 0 {
+		return errors.New("inform the bundle image via the --image flag or the bundle directory via the --dir flag")
+	}
+
+	if len(flags.BundleImage) > 0 && len(flags.BundleDir) > 0 {
+		return errors.New("the --image and --dir flags cannot be used together")
+	}
+
+	if len(flags.BundleDir) > 0 {
+		if _, err := os.Stat(flags.BundleDir); os.IsNotExist(err) {
+			return fmt.Errorf("invalid directory path informed via the --dir flag (%s) : %s ",
+				flags.BundleDir, err)
+		}
+		// to ensure that the report and the copy of the bundle have a valid name when e.g. . is informed
+		dir, err := filepath.Abs(flags.BundleDir)
+		if err != nil {
+			return fmt.Errorf("invalid directory path informed via the --dir flag (%s) : %s ",
+				flags.BundleDir, err)
+		}
+		flags.BundleDir = dir
+	}
+
+	if _, err := writers.ParseFormats(flags.OutputFormat); err != nil {
+		return fmt.Errorf("invalid value informed via the --output flag :%s", err)
+	}
+
+	if err := pkg.ValidateJSONFieldNames(bundles.Column{}, flags.Columns); err != nil {
+		return fmt.Errorf("invalid value informed via the --columns flag :%s", err)
+	}
+	if err := bundles.ValidateColumns(flags.Columns); err != nil {
+		return fmt.Errorf("invalid value informed via the --columns flag :%s", err)
+	}
+
+	if len(flags.OutputPath) > 0 {
+		if _, err := os.Stat(flags.OutputPath); os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if !flags.DisableScorecard {
+		if !pkg.HasClusterRunning() {
+			return errors.New("this report is configured to run the Scorecard tests which requires a cluster up " +
+				"and running. Please, startup your cluster or use the flag --disable-scorecard")
+		}
+		if !pkg.HasSDKInstalled() {
+			return errors.New("this report is configured to run the Scorecard tests which requires the " +
+				"SDK CLI version >= 1.5 installed locally.\n" +
+				"Please, see ensure that you have SDK installed or use the flag --disable-scorecard.\n" +
+				"More info: https://github.com/operator-framework/operator-sdk")
+		}
+	}
+
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	log.Info("Starting audit...")
+
+	reportData := bundles.Data{}
+	reportData.Flags = flags
+	pkg.GenerateTemporaryDirs()
+
+	var auditBundle *models.AuditBundle
+	if len(flags.BundleDir) > 0 {
+		var err error
+		auditBundle, err = getDataFromBundleDir(flags.BundleDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		// the name is replaced by the CSV name after the bundle is read
+		name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(flags.BundleImage)
+		auditBundle = actions.GetDataFromBundleImage(models.NewAuditBundle(name, flags.BundleImage),
+			flags.DisableScorecard, flags.DisableValidators, flags.ServerMode)
+	}
+	actions.AddDataFromAnnotations(auditBundle)
+	actions.CheckBundleConsistency(auditBundle, false)
+	actions.AuditRelatedImages(auditBundle, flags.CheckRelatedImages, flags.RelatedImagesRegistry)
+	reportData.AuditBundle = append(reportData.AuditBundle, *auditBundle)
+
+	log.Infof("Start to generate the report")
+	if err := reportData.OutputReport(); err != nil {
+		return err
+	}
+
+	pkg.CleanupTemporaryDirs()
+	log.Infof("Operation completed.")
+
+	return nil
+}
+
+// getDataFromBundleDir audits a copy of the bundle directory, since the scorecard config is written in it
+func getDataFromBundleDir(dir string) (*models.AuditBundle, error) {
+	auditBundle := models.NewAuditBundle(filepath.Base(dir), "")
+
+	tmpDir := filepath.Join("tmp", filepath.Base(dir))
+	cmd := exec.Command("cp", "-r", dir, tmpDir)
+	if _, err := pkg.RunCommand(cmd); err != nil {
+		return nil, fmt.Errorf("unable to copy the bundle directory %s : %s", dir, err)
+	}
+
+	return actions.GetDataFromBundleDir(auditBundle, tmpDir, flags.DisableScorecard, flags.DisableValidators), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+func TestValidation(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		flags   bundles.BindFlags
+		wantErr bool
+	}{
+		{
+			name:    "should fail when neither the image nor the directory is informed",
+			flags:   bundles.BindFlags{OutputFormat: "json"},
+			wantErr: true,
+		},
+		{
+			name: "should fail when the image and the directory are informed",
+			flags: bundles.BindFlags{BundleImage: "quay.io/example/memcached-operator-bundle:v0.0.1",
+				BundleDir: dir, OutputFormat: "json"},
+			wantErr: true,
+		},
+		{
+			name:    "should fail when the directory does not exist",
+			flags:   bundles.BindFlags{BundleDir: filepath.Join(dir, "missing"), OutputFormat: "json"},
+			wantErr: true,
+		},
+		{
+			name:    "should fail when the output format is invalid",
+			flags:   bundles.BindFlags{BundleDir: dir, OutputFormat: "pdf"},
+			wantErr: true,
+		},
+		{
+			name:    "should fail when the column is invalid",
+			flags:   bundles.BindFlags{BundleDir: dir, OutputFormat: "json", Columns: []string{"invalid"}},
+			wantErr: true,
+		},
+		{
+			name: "should accept the bundle image",
+			flags: bundles.BindFlags{BundleImage: "quay.io/example/memcached-operator-bundle:v0.0.1",
+				OutputFormat: "json"},
+		},
+		{
+			name:  "should accept the bundle directory",
+			flags: bundles.BindFlags{BundleDir: dir, OutputFormat: "json,md", Columns: []string{"packageName"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags = tt.flags
+			flags.DisableScorecard = true
+			if err := validation(nil, nil); (err != nil) != tt.wantErr {
+				t.Errorf("validation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidationBundleDir(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unable to get the current directory : %v", err)
+	}
+	relative, err := filepath.Rel(wd, dir)
+	if err != nil {
+		t.Fatalf("unable to get the relative path of %s : %v", dir, err)
+	}
+	flags = bundles.BindFlags{BundleDir: relative, OutputFormat: "json", DisableScorecard: true}
+	if err := validation(nil, nil); err != nil {
+		t.Fatalf("validation() error = %v", err)
+	}
+	if flags.BundleDir != dir {
+		t.Errorf("validation() bundle directory = %s, want the absolute path %s", flags.BundleDir, dir)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/reports/custom"
+	"github.com/operator-framework/audit/pkg/writers"
+)
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "dependencies",
+		Short: "generates a report with the dependencies between the packages of the catalog according to the " +
+			"APIs and packages owned and required by the head of their channels",
+		Long: "use this command with the result of `audit index bundles [OPTIONS]` to check if the APIs and " +
+			"packages required by the head bundles are provided by any head bundle of the catalog, the APIs owned " +
+			"by more than one package and the dependency graph between the packages.\n\n " +
+			"**When this command is useful?** \n\n" +
+			"This command is useful to check if all packages of a catalog can be installed with their dependencies.",
+		PreRunE: validation,
+		RunE:    run,
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	cmd.Flags().StringVar(&custom.Flags.File, "file", "",
+		"path of the JSON File result of the command audit-tool index bundles --index-image=<image> [OPTIONS]")
+	if err := cmd.MarkFlagRequired("file"); err != nil {
+		log.Fatalf("Failed to mark `file` flag for `dependencies` sub-command as required")
+	}
+	cmd.Flags().StringVar(&custom.Flags.OutputPath, "output-path", currentPath,
+		"inform the path of the directory to output the report. (Default: current directory)")
+	cmd.Flags().StringVar(&custom.Flags.OutputFormat, "output", pkg.HTML,
+		fmt.Sprintf("inform the output format(s) as a comma-separated list (e.g. json,csv,md). [Flags: %s]",
+			strings.Join(writers.Formats(), ", ")))
+	return cmd
+}
+
+func validation(cmd *cobra.Command, args []string) error {
+	if _, err := writers.ParseFormats(custom.Flags.OutputFormat); err != nil {
+		return fmt.Errorf("invalid value informed via the --output flag :%s", err)
+	}
+	if len(custom.Flags.OutputPath) > 0 {
+		if _, err := os.Stat(custom.Flags.OutputPath); os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	log.Info("Starting ...")
+
+	bundlesReport, err := custom.ParseBundlesJSONReport()
+	if err != nil {
+		return err
+	}
+
+	report := custom.NewDependenciesReport(bundlesReport, custom.Flags.OutputPath)
+	if err := writers.Output(report, custom.Flags.OutputFormat); err != nil {
+		return err
+	}
+
+	log.Infof("Operation completed.")
+	return nil
+}
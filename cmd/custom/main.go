@@ -17,6 +17,7 @@ package custom
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/operator-framework/audit/cmd/custom/dependencies"
 	"github.com/operator-framework/audit/cmd/custom/deprecate"
 	"github.com/operator-framework/audit/cmd/custom/grade"
 )
@@ -30,6 +31,7 @@ func NewCmd() *cobra.Command {
 	indexCmd.AddCommand(
 		deprecate.NewCmd(),
 		grade.NewCmd(),
+		dependencies.NewCmd(),
 	)
 
 	return indexCmd
@@ -20,10 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/operator-framework/audit/pkg/actions"
 
+	"github.com/blang/semver"
 	"github.com/spf13/cobra"
 
 	// To allow create connection to query the index database
@@ -34,6 +36,7 @@ import (
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
 	index "github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 var flags = index.BindFlags{}
@@ -64,9 +67,23 @@ func NewCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.Filter, "filter", "",
 		"filter by the packages names which are like *filter*")
+	cmd.Flags().StringVar(&flags.FilterRegex, "filter-regex", "",
+		"filter by the packages names which match the regex informed (e.g. ^(etcd|mongodb)-.*)")
+	cmd.Flags().StringVar(&flags.PackageList, "package-list", "",
+		"inform the path of a file with the exact names of the packages to be audit (one per line)")
+	cmd.Flags().StringVar(&flags.Channel, "channel", "",
+		"filter by the bundles which are in the channel informed")
+	cmd.Flags().BoolVar(&flags.DefaultChannelOnly, "default-channel-only", false,
+		"if set, will just check the operator bundles which are in the default channel of their packages")
+	cmd.Flags().StringVar(&flags.VersionRange, "version-range", "",
+		"filter by the bundles versions which are in the semver range informed (e.g. \">=1.0.0 <2.0.0\")")
+	cmd.Flags().StringSliceVar(&flags.Properties, "property", []string{},
+		"filter by the bundles which have the property type informed (e.g. olm.maxOpenShiftVersion) or "+
+			"the type with a value which contains *value* (e.g. olm.maxOpenShiftVersion=4.8). "+
+			"This flag can be informed more than once")
 	cmd.Flags().StringVar(&flags.OutputFormat, "output", pkg.Xls,
-		fmt.Sprintf("inform the output format. [Flags: %s, %s, %s]", pkg.JSON,
-			pkg.Xls, pkg.All))
+		fmt.Sprintf("inform the output format(s) as a comma-separated list (e.g. json,csv,md). [Flags: %s]",
+			strings.Join(writers.Formats(), ", ")))
 	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
 		"inform the path of the directory to output the report. (Default: current directory)")
 	cmd.Flags().Int32Var(&flags.Limit, "limit", 0,
@@ -77,14 +94,30 @@ func NewCmd() *cobra.Command {
 		"if set, will disable the scorecard tests")
 	cmd.Flags().BoolVar(&flags.DisableValidators, "disable-validators", false,
 		"if set, will disable the validators tests")
+	cmd.Flags().BoolVar(&flags.CheckRelatedImages, "check-related-images", false,
+		"if set, will inspect the images referenced by the bundles to check that they exist and their architectures")
+	cmd.Flags().StringVar(&flags.RelatedImagesRegistry, "related-images-registry", "",
+		"inform the registry (e.g. localhost:5000) where the related images are mirrored to check them in it "+
+			"instead of in their registries. Only used with --check-related-images")
 	cmd.Flags().StringVar(&flags.Label, "label", "",
 		"filter by bundles which has index images where contains *label*")
 	cmd.Flags().StringVar(&flags.LabelValue, "label-value", "",
 		"filter by bundles which has index images where contains *label=label-value*. "+
 			"This option can only be used with the --label flag.")
+	cmd.Flags().StringArrayVar(&flags.LabelSelectors, "label-selector", []string{},
+		"filter by bundles which have bundle images with labels matching the selector, which supports "+
+			"the operators =, !=, in, notin and exists (e.g. \"com.redhat.openshift.versions in (v4.7,v4.8)\" or "+
+			"\"!com.redhat.delivery.backport\"). This flag can be informed more than once and the bundles "+
+			"which match any of the selectors are audit. Note that the labels are checked before pulling the images")
 	cmd.Flags().BoolVar(&flags.ServerMode, "server-mode", false,
 		"if set, the images which are downloaded will not be removed. This flag should be used on dedicated "+
 			"environments and reduce the cost to generate the reports periodically")
+	cmd.Flags().StringSliceVar(&flags.Columns, "columns", []string{},
+		"inform the columns (JSON field names) to output and their order (e.g. packageName,bundleName,sdkVersion). "+
+			"(Default: all columns)")
+	cmd.Flags().StringVar(&flags.SortBy, "sort-by", "",
+		"inform the column (JSON field name) used to sort the results. Use the suffix :desc to sort in "+
+			"descending order (e.g. bundleImageBuildDate:desc). (Default: packageName)")
 
 	return cmd
 }
@@ -95,10 +128,22 @@ func validation(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid value informed via the --limit flag :%v", flags.Limit)
 	}
 
-	if len(flags.OutputFormat) > 0 && flags.OutputFormat != pkg.JSON &&
-		flags.OutputFormat != pkg.Xls && flags.OutputFormat != pkg.All {
-		return fmt.Errorf("invalid value informed via the --output flag :%v. "+
-			"The available options are: %s, %s and %s", flags.OutputFormat, pkg.JSON, pkg.Xls, pkg.All)
+	if _, err := writers.ParseFormats(flags.OutputFormat); err != nil {
+		return fmt.Errorf("invalid value informed via the --output flag :%s", err)
+	}
+
+	if err := pkg.ValidateJSONFieldNames(index.Column{}, flags.Columns); err != nil {
+		return fmt.Errorf("invalid value informed via the --columns flag :%s", err)
+	}
+	if err := index.ValidateColumns(flags.Columns); err != nil {
+		return fmt.Errorf("invalid value informed via the --columns flag :%s", err)
+	}
+
+	if len(flags.SortBy) > 0 {
+		field, _ := pkg.ParseSortBy(flags.SortBy)
+		if err := pkg.ValidateJSONFieldNames(index.Column{}, []string{field}); err != nil {
+			return fmt.Errorf("invalid value informed via the --sort-by flag :%s", err)
+		}
 	}
 
 	if len(flags.OutputPath) > 0 {
@@ -107,10 +152,39 @@ func validation(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if len(flags.LabelValue) > 0 && len(flags.Label) < 0 {
+	if len(flags.FilterRegex) > 0 {
+		if _, err := regexp.Compile(flags.FilterRegex); err != nil {
+			return fmt.Errorf("invalid value informed via the --filter-regex flag :%s", err)
+		}
+	}
+
+	if len(flags.PackageList) > 0 {
+		if _, err := os.Stat(flags.PackageList); os.IsNotExist(err) {
+			return fmt.Errorf("invalid file path informed via the --package-list flag (%s) : %s ",
+				flags.PackageList, err)
+		}
+	}
+
+	if len(flags.VersionRange) > 0 {
+		if _, err := semver.ParseRange(flags.VersionRange); err != nil {
+			return fmt.Errorf("invalid value informed via the --version-range flag :%s", err)
+		}
+	}
+
+	for _, property := range flags.Properties {
+		if len(strings.TrimSpace(strings.Split(property, "=")[0])) == 0 {
+			return fmt.Errorf("invalid value informed via the --property flag :%s", property)
+		}
+	}
+
+	if len(flags.LabelValue) > 0 && len(flags.Label) == 0 {
 		return fmt.Errorf("inform the label via the --label flag")
 	}
 
+	if _, err := pkg.LabelSelectors(flags.Label, flags.LabelValue, flags.LabelSelectors); err != nil {
+		return fmt.Errorf("invalid value informed via the label flags :%s", err)
+	}
+
 	if !flags.DisableScorecard {
 		if !pkg.HasClusterRunning() {
 			return errors.New("this report is configured to run the Scorecard tests which requires a cluster up " +
@@ -148,7 +222,7 @@ func run(cmd *cobra.Command, args []string) error {
 		log.Errorf("unable to inspect the index image: %s", err)
 	}
 
-	if err := actions.ExtractIndexDB(flags.IndexImage); err != nil {
+	if err := actions.ExtractIndexDB(flags.IndexImage, reportData.IndexImageInspect); err != nil {
 		return err
 	}
 
@@ -175,18 +249,38 @@ func getDataFromIndexDB(report index.Data) (index.Data, error) {
 		return report, fmt.Errorf("unable to connect in to the database : %s", err)
 	}
 
-	sql, err := report.BuildBundlesQuery()
+	if report.HasPackageNamesFilter() {
+		indexPackages, err := getPackageNames(db)
+		if err != nil {
+			return report, err
+		}
+		if err := report.ResolvePackageNames(indexPackages); err != nil {
+			return report, err
+		}
+	}
+
+	selectors, err := pkg.LabelSelectors(report.Flags.Label, report.Flags.LabelValue, report.Flags.LabelSelectors)
+	if err != nil {
+		return report, err
+	}
+
+	sql, args, err := report.BuildBundlesQuery()
 	if err != nil {
 		return report, err
 	}
 
-	row, err := db.Query(sql)
+	row, err := db.Query(sql, args...)
 	if err != nil {
 		return report, fmt.Errorf("unable to query the index db : %s", err)
 	}
 
 	defer row.Close()
 	for row.Next() {
+		// the limit is not applied in the query when the versions or labels need to be checked
+		if report.Flags.Limit > 0 && len(report.AuditBundle) >= int(report.Flags.Limit) {
+			break
+		}
+
 		var bundleName string
 		var csv *string
 		var bundlePath string
@@ -201,7 +295,15 @@ func getDataFromIndexDB(report index.Data) (index.Data, error) {
 			log.Errorf("unable to scan data from index %s\n", err.Error())
 		}
 
+		if !report.IsInVersionRange(version) {
+			continue
+		}
+
 		auditBundle := models.NewAuditBundle(bundleName, bundlePath)
+		if !actions.MatchBundleLabels(auditBundle, selectors) {
+			log.Infof("skipping the bundle %s since its labels do not match the criteria", bundleName)
+			continue
+		}
 
 		// the csv is pruned from the database to save space.
 		// See that is store only what is needed to populate the package manifest on cluster, all the extra
@@ -222,7 +324,8 @@ func getDataFromIndexDB(report index.Data) (index.Data, error) {
 		auditBundle.SkipsDB = skips
 
 		auditBundle = actions.GetDataFromBundleImage(auditBundle, report.Flags.DisableScorecard,
-			report.Flags.DisableValidators, report.Flags.ServerMode, report.Flags.Label, report.Flags.LabelValue)
+			report.Flags.DisableValidators, report.Flags.ServerMode)
+		actions.AuditRelatedImages(auditBundle, report.Flags.CheckRelatedImages, report.Flags.RelatedImagesRegistry)
 
 		sqlString := fmt.Sprintf("SELECT c.channel_name, c.package_name FROM channel_entry c "+
 			"where c.operatorbundle_name = '%s'", auditBundle.OperatorBundleName)
@@ -273,22 +376,45 @@ func getDataFromIndexDB(report index.Data) (index.Data, error) {
 				pkg.PropertiesAnnotation{Type: properType, Value: properValue})
 		}
 
-		sqlString = fmt.Sprintf("select count(*) from channel where head_operatorbundle_name = '%s'",
-			auditBundle.OperatorBundleName)
+		sqlString = fmt.Sprintf("select name, head_operatorbundle_name from channel where "+
+			"head_operatorbundle_name = '%s'", auditBundle.OperatorBundleName)
 		row, err = db.Query(sqlString)
 		if err != nil {
-			return report, fmt.Errorf("unable to query properties entry in the index db : %s", err)
+			return report, fmt.Errorf("unable to query the channel heads in the index db : %s", err)
 		}
 
 		defer row.Close()
-		var found int
 		for row.Next() { // Iterate and fetch the records from result cursor
-			_ = row.Scan(&found)
-			auditBundle.IsHeadOfChannel = found > 0
+			var channelName string
+			var headName string
+			_ = row.Scan(&channelName, &headName)
+			auditBundle.HeadOfChannels = append(auditBundle.HeadOfChannels, channelName)
 		}
+		auditBundle.IsHeadOfChannel = len(auditBundle.HeadOfChannels) > 0
 
+		actions.CheckBundleConsistency(auditBundle, true)
 		report.AuditBundle = append(report.AuditBundle, *auditBundle)
 	}
 
 	return report, nil
 }
+
+// getPackageNames returns the names of all packages in the index db
+func getPackageNames(db *sql.DB) ([]string, error) {
+	row, err := db.Query("SELECT name FROM package")
+	if err != nil {
+		return nil, fmt.Errorf("unable to query the packages in the index db : %s", err)
+	}
+	defer row.Close()
+
+	var names []string
+	for row.Next() {
+		var name string
+		if err := row.Scan(&name); err != nil {
+			log.Errorf("unable to scan data from index %s\n", err.Error())
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
 	"github.com/operator-framework/audit/pkg/reports/channels"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 var flags = channels.BindFlags{}
@@ -65,8 +66,8 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().Int32Var(&flags.Limit, "limit", 0,
 		"limit the num of packages to be audit")
 	cmd.Flags().StringVar(&flags.OutputFormat, "output", pkg.Xls,
-		fmt.Sprintf("inform the output format. [Flags: %s, %s, %s]", pkg.JSON,
-			pkg.Xls, pkg.All))
+		fmt.Sprintf("inform the output format(s) as a comma-separated list (e.g. json,csv,md). [Flags: %s]",
+			strings.Join(writers.Formats(), ", ")))
 	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
 		"inform the path of the directory to output the report. (Default: current directory)")
 
@@ -79,10 +80,8 @@ func validation(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid value informed via the --limit flag :%v", flags.Limit)
 	}
 
-	if len(flags.OutputFormat) > 0 && flags.OutputFormat != pkg.JSON &&
-		flags.OutputFormat != pkg.Xls && flags.OutputFormat != pkg.All {
-		return fmt.Errorf("invalid value informed via the --output flag :%v. "+
-			"The available options are: %s, %s and %s", flags.OutputFormat, pkg.JSON, pkg.Xls, pkg.All)
+	if _, err := writers.ParseFormats(flags.OutputFormat); err != nil {
+		return fmt.Errorf("invalid value informed via the --output flag :%s", err)
 	}
 
 	if len(flags.OutputPath) > 0 {
@@ -115,7 +114,7 @@ func run(cmd *cobra.Command, args []string) error {
 		log.Errorf("unable to inspect the index image: %s", err)
 	}
 
-	if err := actions.ExtractIndexDB(flags.IndexImage); err != nil {
+	if err := actions.ExtractIndexDB(flags.IndexImage, reportData.IndexImageInspect); err != nil {
 		return err
 	}
 
@@ -35,6 +35,7 @@ import (
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
 	"github.com/operator-framework/audit/pkg/reports/packages"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 var flags = packages.BindFlags{}
@@ -69,8 +70,8 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().Int32Var(&flags.Limit, "limit", 0,
 		"limit the num of packages to be audit")
 	cmd.Flags().StringVar(&flags.OutputFormat, "output", pkg.Xls,
-		fmt.Sprintf("inform the output format. [Flags: %s, %s, %s]", pkg.JSON,
-			pkg.Xls, pkg.All))
+		fmt.Sprintf("inform the output format(s) as a comma-separated list (e.g. json,csv,md). [Flags: %s]",
+			strings.Join(writers.Formats(), ", ")))
 	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
 		"inform the path of the directory to output the report. (Default: current directory)")
 	cmd.Flags().BoolVar(&flags.DisableScorecard, "disable-scorecard", false,
@@ -82,6 +83,11 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().StringVar(&flags.LabelValue, "label-value", "",
 		"filter by packages which has bundles with index images where contains *label=label-value*. "+
 			"This option can only be used with the --label flag.")
+	cmd.Flags().StringArrayVar(&flags.LabelSelectors, "label-selector", []string{},
+		"filter by packages which have bundle images with labels matching the selector, which supports "+
+			"the operators =, !=, in, notin and exists (e.g. \"com.redhat.openshift.versions in (v4.7,v4.8)\" or "+
+			"\"!com.redhat.delivery.backport\"). This flag can be informed more than once and the bundles "+
+			"which match any of the selectors are audit. Note that the labels are checked before pulling the images")
 	cmd.Flags().BoolVar(&flags.ServerMode, "server-mode", false,
 		"if set, the images which are downloaded will not be removed. This flag should be used on dedicated "+
 			"environments and reduce the cost to generate the reports periodically")
@@ -95,10 +101,8 @@ func validation(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid value informed via the --limit flag :%v", flags.Limit)
 	}
 
-	if len(flags.OutputFormat) > 0 && flags.OutputFormat != pkg.JSON &&
-		flags.OutputFormat != pkg.Xls && flags.OutputFormat != pkg.All {
-		return fmt.Errorf("invalid value informed via the --output flag :%v. "+
-			"The available options are: %s, %s and %s", flags.OutputFormat, pkg.JSON, pkg.Xls, pkg.All)
+	if _, err := writers.ParseFormats(flags.OutputFormat); err != nil {
+		return fmt.Errorf("invalid value informed via the --output flag :%s", err)
 	}
 
 	if len(flags.OutputPath) > 0 {
@@ -108,10 +112,14 @@ func validation(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if len(flags.LabelValue) > 0 && len(flags.Label) < 0 {
+	if len(flags.LabelValue) > 0 && len(flags.Label) == 0 {
 		return fmt.Errorf("inform the label via the --label flag")
 	}
 
+	if _, err := pkg.LabelSelectors(flags.Label, flags.LabelValue, flags.LabelSelectors); err != nil {
+		return fmt.Errorf("invalid value informed via the label flags :%s", err)
+	}
+
 	if !flags.DisableScorecard {
 		if !pkg.HasClusterRunning() {
 			return errors.New("this report is configured to run the Scorecard tests which requires a cluster up " +
@@ -147,7 +155,7 @@ func run(cmd *cobra.Command, args []string) error {
 		log.Errorf("unable to inspect the index image: %s", err)
 	}
 
-	if err := actions.ExtractIndexDB(flags.IndexImage); err != nil {
+	if err := actions.ExtractIndexDB(flags.IndexImage, reportData.IndexImageInspect); err != nil {
 		return err
 	}
 
@@ -174,6 +182,11 @@ func getDataFromIndexDB(report packages.Data) (packages.Data, error) {
 		return report, fmt.Errorf("unable to connect in to the database : %s", err)
 	}
 
+	selectors, err := pkg.LabelSelectors(report.Flags.Label, report.Flags.LabelValue, report.Flags.LabelSelectors)
+	if err != nil {
+		return report, err
+	}
+
 	sql, err := report.BuildPackagesQuery()
 	if err != nil {
 		return report, err
@@ -243,6 +256,10 @@ func getDataFromIndexDB(report packages.Data) (packages.Data, error) {
 			}
 
 			auditBundle := models.NewAuditBundle(bundleName, bundlePath)
+			if !actions.MatchBundleLabels(auditBundle, selectors) {
+				log.Infof("skipping the bundle %s since its labels do not match the criteria", bundleName)
+				continue
+			}
 			// the csv is pruned from the database to save space.
 			// See that is store only what is needed to populate the package manifest on cluster, all the extra
 			// manifests are pruned to save storage space
@@ -257,8 +274,7 @@ func getDataFromIndexDB(report packages.Data) (packages.Data, error) {
 			}
 
 			auditBundle = actions.GetDataFromBundleImage(auditBundle,
-				report.Flags.DisableScorecard, report.Flags.DisableValidators, report.Flags.ServerMode,
-				report.Flags.Label, report.Flags.LabelValue)
+				report.Flags.DisableScorecard, report.Flags.DisableValidators, report.Flags.ServerMode)
 
 			if len(strings.TrimSpace(auditBundle.PackageName)) == 0 && auditBundle.Bundle != nil {
 				auditBundle.PackageName = auditBundle.Bundle.Package
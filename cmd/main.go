@@ -15,15 +15,33 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"runtime"
 
+	"github.com/operator-framework/audit/cmd/batch"
+	"github.com/operator-framework/audit/cmd/bundle"
 	"github.com/operator-framework/audit/cmd/custom"
 	"github.com/operator-framework/audit/cmd/index"
+	"github.com/operator-framework/audit/cmd/schema"
+	"github.com/operator-framework/audit/cmd/serve"
+	"github.com/operator-framework/audit/cmd/site"
+	"github.com/operator-framework/audit/pkg"
 
 	"github.com/spf13/cobra"
 )
 
+// Injected via the ldflags by the Makefile
+var (
+	version   = "unknown"
+	gitCommit = "unknown"
+	buildDate = "unknown"
+	goos      = runtime.GOOS
+	goarch    = runtime.GOARCH
+)
+
 func main() {
+	pkg.BuildInfo = pkg.Build{Version: version, GitCommit: gitCommit, BuildDate: buildDate}
 
 	rootCmd := &cobra.Command{
 		Use:   "audit-tool",
@@ -36,10 +54,16 @@ func main() {
 			"of the report, image name and date. " +
 			"(E.g. `testdata/report/bundles_quay.io_operatorhubio_catalog_latest_2021-04-22.xlsx`)" +
 			"For further information use the --help and check : https://github.com/operator-framework/audit",
+		Version: fmt.Sprintf("%s, commit: %s, build date: %s, %s/%s", version, gitCommit, buildDate, goos, goarch),
 	}
 
 	rootCmd.AddCommand(index.NewCmd())
 	rootCmd.AddCommand(custom.NewCmd())
+	rootCmd.AddCommand(bundle.NewCmd())
+	rootCmd.AddCommand(serve.NewCmd())
+	rootCmd.AddCommand(batch.NewCmd())
+	rootCmd.AddCommand(site.NewCmd())
+	rootCmd.AddCommand(schema.NewCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
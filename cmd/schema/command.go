@@ -0,0 +1,86 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/catalogs"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/reports/channels"
+	"github.com/operator-framework/audit/pkg/reports/packages"
+)
+
+// BindFlags define the flags used by the schema command
+type BindFlags struct {
+	OutputPath string `json:"outputPath"`
+}
+
+var flags = BindFlags{}
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "output the JSON Schema of the reports",
+		Long: "Outputs the JSON Schema of each report kind (e.g. bundles.schema.json) with the schema version " +
+			"supported by this version of the audit-tool.\n\n " +
+			"**When this command is useful?** \n\n" +
+			"This command is useful to validate the JSON reports before consuming them.",
+		RunE: run,
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
+		"inform the path of the directory to output the schemas. (Default: current directory)")
+
+	return cmd
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	schemas := map[string]*pkg.JSONSchema{
+		"bundles":  bundles.Schema(),
+		"packages": packages.Schema(),
+		"channels": channels.Schema(),
+	}
+	if err := os.MkdirAll(flags.OutputPath, os.ModePerm); err != nil {
+		return err
+	}
+	for _, kind := range catalogs.Kinds {
+		schema := schemas[kind]
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(flags.OutputPath, fmt.Sprintf("%s.schema.json", kind))
+		if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("unable to write the schema %s : %s", path, err)
+		}
+		log.Infof("Schema %s %s written in %s", kind, pkg.SchemaVersion, path)
+	}
+	return nil
+}
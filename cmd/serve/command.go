@@ -0,0 +1,121 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg/catalogs"
+	"github.com/operator-framework/audit/pkg/server"
+)
+
+// BindFlags define the flags used by the serve command
+type BindFlags struct {
+	Config        string `json:"config"`
+	Address       string `json:"address"`
+	DataDir       string `json:"dataDir"`
+	History       int    `json:"history"`
+	GradeTemplate string `json:"gradeTemplate"`
+	MetricsFile   string `json:"metricsFile"`
+}
+
+var flags = BindFlags{}
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "run a server which audits the catalogs periodically and exposes the results via an API",
+		Long: "Starts a long-running server which audits periodically the index catalogs informed in the config " +
+			"file and keeps their latest results. The results are exposed via REST endpoints (e.g. " +
+			"/api/catalogs) and the HTML dashboards are served per catalog.\n\n " +
+			"**When this command is useful?** \n\n" +
+			"This command is useful when the reports are required to be checked and shared by a team.",
+		PreRunE: validation,
+		RunE:    run,
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	cmd.Flags().StringVar(&flags.Config, "config", "",
+		"path of the YAML file with the catalogs which will be audit (e.g. catalogs.yaml)")
+	if err := cmd.MarkFlagRequired("config"); err != nil {
+		log.Fatalf("Failed to mark `config` flag for `serve` sub-command as required")
+	}
+	cmd.Flags().StringVar(&flags.Address, "address", ":8080",
+		"address which the server will listen to")
+	cmd.Flags().StringVar(&flags.DataDir, "data-dir", filepath.Join(currentPath, "audit-data"),
+		"path of the directory where the results of the audits are stored")
+	cmd.Flags().IntVar(&flags.History, "history", 10,
+		"number of runs kept per catalog, which can be diffed via the API")
+	cmd.Flags().StringVar(&flags.GradeTemplate, "grade-template", server.DefaultGradeTemplate(currentPath),
+		"path of the template used to render the grade dashboard")
+	cmd.Flags().StringVar(&flags.MetricsFile, "metrics-file", "",
+		"if informed, the Prometheus metrics are written in this file after each run, which allows use "+
+			"the textfile collector of the node exporter (e.g. /var/lib/node_exporter/audit.prom)")
+
+	return cmd
+}
+
+func validation(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(flags.Config); os.IsNotExist(err) {
+		return fmt.Errorf("invalid file path informed via the --config flag (%s) : %s ", flags.Config, err)
+	}
+	if flags.History < 2 {
+		return fmt.Errorf("invalid value informed via the --history flag :%v. It should be at least 2",
+			flags.History)
+	}
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	config, err := catalogs.LoadConfig(flags.Config)
+	if err != nil {
+		return err
+	}
+
+	// the reports are generated by running the audit-tool itself, the same way that is done by the hack scripts
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the audit-tool binary : %s", err)
+	}
+
+	s, err := server.New(server.Options{
+		Config:        config,
+		DataDir:       flags.DataDir,
+		History:       flags.History,
+		Binary:        binary,
+		GradeTemplate: flags.GradeTemplate,
+		MetricsFile:   flags.MetricsFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Start(context.Background())
+
+	log.Infof("Listening on %s", flags.Address)
+	return http.ListenAndServe(flags.Address, s.Handler())
+}
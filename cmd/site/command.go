@@ -0,0 +1,88 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package site
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/audit/pkg/site"
+)
+
+// BindFlags define the flags used by the site command
+type BindFlags struct {
+	ReportsDir string `json:"reportsDir"`
+	OutputPath string `json:"outputPath"`
+}
+
+var flags = BindFlags{}
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "site",
+		Short: "build a static site to browse the reports of a directory",
+		Long: "Builds a static site with an index page of the catalogs, a page per catalog with the reports of " +
+			"each tag and links to download them and to their dashboards, and a page per package with its " +
+			"bundles. The catalog, tag, kind and date are obtained from the metadata of the JSON reports.\n\n " +
+			"**When this command is useful?** \n\n" +
+			"This command is useful to publish the reports, e.g. via GitHub Pages.",
+		PreRunE: validation,
+		RunE:    run,
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	cmd.Flags().StringVar(&flags.ReportsDir, "reports-dir", "",
+		"path of the directory with the JSON reports (e.g. testdata/reports)")
+	if err := cmd.MarkFlagRequired("reports-dir"); err != nil {
+		log.Fatalf("Failed to mark `reports-dir` flag for `site` sub-command as required")
+	}
+	cmd.Flags().StringVar(&flags.OutputPath, "output-path", currentPath,
+		"inform the path of the directory to output the site. (Default: current directory)")
+
+	return cmd
+}
+
+func validation(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(flags.ReportsDir); os.IsNotExist(err) {
+		return fmt.Errorf("invalid path informed via the --reports-dir flag (%s) : %s ", flags.ReportsDir, err)
+	}
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	// the links of the pages are relative, so that the site can be served from any place
+	reportsDir, err := filepath.Abs(flags.ReportsDir)
+	if err != nil {
+		return err
+	}
+	outputPath, err := filepath.Abs(flags.OutputPath)
+	if err != nil {
+		return err
+	}
+	if err := site.Build(reportsDir, outputPath); err != nil {
+		return err
+	}
+	log.Infof("Site generated in %s", outputPath)
+	return nil
+}
@@ -11,5 +11,6 @@ require (
 	github.com/operator-framework/api v0.9.2-0.20210527192522-337546fae293
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v1.1.1
+	k8s.io/api v0.20.1
 	k8s.io/apimachinery v0.20.1
 )
@@ -0,0 +1,123 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+const mediatypeAnnotation = "operators.operatorframework.io.bundle.mediatype.v1"
+const manifestsAnnotation = "operators.operatorframework.io.bundle.manifests.v1"
+const metadataAnnotation = "operators.operatorframework.io.bundle.metadata.v1"
+
+// bundleLabels are the labels of the bundle image which must match its metadata/annotations.yaml
+var bundleLabels = []string{
+	packageAnnotation,
+	channelsAnnotation,
+	defaultChannelAnnotation,
+	mediatypeAnnotation,
+	manifestsAnnotation,
+	metadataAnnotation,
+}
+
+// CheckBundleConsistency sets the inconsistencies between the bundle image labels and the
+// metadata/annotations.yaml of the bundle. If checkIndex is true then the package and channels of the
+// annotations are also compared with the channel entries of the index db set in the auditBundle.
+func CheckBundleConsistency(auditBundle *models.AuditBundle, checkIndex bool) {
+	annotations := auditBundle.BundleAnnotations
+	if annotations == nil {
+		return
+	}
+
+	// The labels are not available when the bundle is audit from a directory
+	if auditBundle.BundleImageLabels != nil {
+		for _, key := range bundleLabels {
+			label, hasLabel := auditBundle.BundleImageLabels[key]
+			annotation, hasAnnotation := annotations[key]
+			switch {
+			case !hasLabel && !hasAnnotation:
+			case !hasLabel:
+				auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+					fmt.Sprintf("the label %s is not set in the bundle image but annotations.yaml has %s",
+						key, annotation))
+			case !hasAnnotation:
+				auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+					fmt.Sprintf("the label %s of the bundle image (%s) is not in annotations.yaml", key, label))
+			case !equalValues(key, label, annotation):
+				auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+					fmt.Sprintf("the label %s of the bundle image (%s) does not match annotations.yaml (%s)",
+						key, label, annotation))
+			}
+		}
+	}
+
+	if !checkIndex {
+		return
+	}
+	if pkgName, found := annotations[packageAnnotation]; found && pkgName != auditBundle.PackageName {
+		auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+			fmt.Sprintf("the package %s of annotations.yaml does not match the package %s of the index",
+				pkgName, auditBundle.PackageName))
+	}
+	indexChannels := strings.Join(pkg.GetUniqueValues(auditBundle.Channels), ",")
+	if channels, found := annotations[channelsAnnotation]; found &&
+		!equalValues(channelsAnnotation, channels, indexChannels) {
+		auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+			fmt.Sprintf("the channels %s of annotations.yaml do not match the channels %s of the index",
+				channels, indexChannels))
+	}
+	// The default channel of the package is defined by the head of its default channel
+	defaultChannel, found := annotations[defaultChannelAnnotation]
+	if found && isHeadOfChannel(auditBundle, auditBundle.DefaultChannel) && defaultChannel != auditBundle.DefaultChannel {
+		auditBundle.BundleInconsistencies = append(auditBundle.BundleInconsistencies,
+			fmt.Sprintf("the default channel %s of annotations.yaml does not match the default channel %s of "+
+				"the index", defaultChannel, auditBundle.DefaultChannel))
+	}
+}
+
+// isHeadOfChannel returns true when the bundle is the head of the channel in the index db
+func isHeadOfChannel(auditBundle *models.AuditBundle, channel string) bool {
+	for _, v := range auditBundle.HeadOfChannels {
+		if v == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares the values of the bundle label, where the channels are compared regardless of their order
+func equalValues(key, a, b string) bool {
+	if key != channelsAnnotation {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return strings.Join(sortedChannels(a), ",") == strings.Join(sortedChannels(b), ",")
+}
+
+func sortedChannels(value string) []string {
+	var channels []string
+	for _, v := range strings.Split(value, ",") {
+		if len(strings.TrimSpace(v)) > 0 {
+			channels = append(channels, strings.TrimSpace(v))
+		}
+	}
+	channels = pkg.GetUniqueValues(channels)
+	sort.Strings(channels)
+	return channels
+}
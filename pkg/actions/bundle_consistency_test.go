@@ -0,0 +1,94 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+func TestCheckBundleConsistency(t *testing.T) {
+	annotations := map[string]string{
+		packageAnnotation:        "etcd",
+		channelsAnnotation:       "alpha,stable",
+		defaultChannelAnnotation: "stable",
+		mediatypeAnnotation:      "registry+v1",
+		manifestsAnnotation:      "manifests/",
+		metadataAnnotation:       "metadata/",
+	}
+	tests := []struct {
+		name       string
+		bundle     models.AuditBundle
+		checkIndex bool
+		want       []string
+	}{
+		{
+			name: "should not report when the labels match regardless of the order of the channels",
+			bundle: models.AuditBundle{BundleAnnotations: annotations, BundleImageLabels: map[string]string{
+				packageAnnotation:        "etcd",
+				channelsAnnotation:       "stable, alpha",
+				defaultChannelAnnotation: "stable",
+				mediatypeAnnotation:      "registry+v1",
+				manifestsAnnotation:      "manifests/",
+				metadataAnnotation:       "metadata/",
+			}},
+		},
+		{
+			name: "should report the labels which do not match",
+			bundle: models.AuditBundle{BundleAnnotations: annotations, BundleImageLabels: map[string]string{
+				packageAnnotation:        "etcd",
+				channelsAnnotation:       "alpha",
+				defaultChannelAnnotation: "stable",
+				manifestsAnnotation:      "manifests/",
+				metadataAnnotation:       "metadata/",
+			}},
+			want: []string{
+				"the label operators.operatorframework.io.bundle.channels.v1 of the bundle image (alpha) " +
+					"does not match annotations.yaml (alpha,stable)",
+				"the label operators.operatorframework.io.bundle.mediatype.v1 is not set in the bundle image " +
+					"but annotations.yaml has registry+v1",
+			},
+		},
+		{
+			name: "should report the channel entries of the index which do not match",
+			bundle: models.AuditBundle{BundleAnnotations: annotations, PackageName: "etcd",
+				Channels: []string{"stable", "stable"}, DefaultChannel: "alpha", IsHeadOfChannel: true,
+				HeadOfChannels: []string{"alpha"}},
+			checkIndex: true,
+			want: []string{
+				"the channels alpha,stable of annotations.yaml do not match the channels stable of the index",
+				"the default channel stable of annotations.yaml does not match the default channel alpha of " +
+					"the index",
+			},
+		},
+		{
+			name: "should not check the default channel of the bundles which are heads of other channels",
+			bundle: models.AuditBundle{BundleAnnotations: annotations, PackageName: "etcd",
+				Channels: []string{"alpha", "stable"}, DefaultChannel: "alpha", IsHeadOfChannel: true,
+				HeadOfChannels: []string{"stable"}},
+			checkIndex: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CheckBundleConsistency(&tt.bundle, tt.checkIndex)
+			if !reflect.DeepEqual(tt.bundle.BundleInconsistencies, tt.want) {
+				t.Errorf("CheckBundleConsistency() = %v, want %v", tt.bundle.BundleInconsistencies, tt.want)
+			}
+		})
+	}
+}
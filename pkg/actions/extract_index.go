@@ -16,20 +16,31 @@ package actions
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/operator-framework/audit/pkg"
 )
 
-const catalogIndex = "audit-catalog-index"
+const catalogIndexPrefix = "audit-catalog-index"
+
+// ExtractIndexDB extracts the index.db from the image into the ./output dir. Note that the image ID
+// from the inspect is used when it is informed, so that the index.db is from the image which was
+// inspected, and then, from the catalog digest recorded in the reports, even if the tag is updated.
+// The container is named with the pid, so that the audits which run at the same time (e.g. via the
+// batch command) do not remove or extract the container of each other.
+func ExtractIndexDB(image string, inspect pkg.DockerInspectManifest) error {
+	catalogIndex := fmt.Sprintf("%s-%d", catalogIndexPrefix, os.Getpid())
 
-func ExtractIndexDB(image string) error {
 	// Remove image if exists already
 	command := exec.Command("docker", "rm", catalogIndex)
 	_, _ = pkg.RunCommand(command)
+	defer func() {
+		_, _ = pkg.RunCommand(exec.Command("docker", "rm", catalogIndex))
+	}()
 
 	// Download the image
-	command = exec.Command("docker", "create", "--name", catalogIndex, image, "\"yes\"")
+	command = exec.Command("docker", "create", "--name", catalogIndex, PinnedImage(image, inspect), "\"yes\"")
 	_, err := pkg.RunCommand(command)
 	if err != nil {
 		return fmt.Errorf("unable to create container image %s : %s", image, err)
@@ -43,3 +54,11 @@ func ExtractIndexDB(image string) error {
 	}
 	return nil
 }
+
+// PinnedImage returns the ID of the image inspected or the image informed when it was not inspected
+func PinnedImage(image string, inspect pkg.DockerInspectManifest) string {
+	if len(inspect.ID) > 0 {
+		return inspect.ID
+	}
+	return image
+}
@@ -19,16 +19,22 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/goccy/go-yaml"
 	apimanifests "github.com/operator-framework/api/pkg/manifests"
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
 	log "github.com/sirupsen/logrus"
 )
 
+const packageAnnotation = "operators.operatorframework.io.bundle.package.v1"
+const channelsAnnotation = "operators.operatorframework.io.bundle.channels.v1"
+const defaultChannelAnnotation = "operators.operatorframework.io.bundle.channel.default.v1"
+
 // Manifest define the manifest.json which is  required to read the bundle
 type Manifest struct {
 	Config string
@@ -37,7 +43,7 @@ type Manifest struct {
 
 // GetDataFromBundleImage returns the bundle from the image
 func GetDataFromBundleImage(auditBundle *models.AuditBundle,
-	disableScorecard, disableValidators, serverMode bool, label, labelValue string) *models.AuditBundle {
+	disableScorecard, disableValidators, serverMode bool) *models.AuditBundle {
 
 	if len(auditBundle.OperatorBundleImagePath) < 1 {
 		auditBundle.Errors = append(auditBundle.Errors,
@@ -52,21 +58,11 @@ func GetDataFromBundleImage(auditBundle *models.AuditBundle,
 		return auditBundle
 	}
 
-	bundleDir := createBundleDir(auditBundle)
-	extractBundleFromImage(auditBundle, bundleDir)
-
 	inspectManifest, err := pkg.RunDockerInspect(auditBundle.OperatorBundleImagePath)
 	if err != nil {
 		auditBundle.Errors = append(auditBundle.Errors, err.Error())
 	} else {
 		// Gathering data by inspecting the operator bundle image
-		if len(label) > 0 {
-			value := inspectManifest.DockerConfig.Labels[label]
-			if value == labelValue {
-				auditBundle.FoundLabel = true
-			}
-		}
-
 		// 4.8 images has note the build-date in the label
 		if len(inspectManifest.Created) > 0 {
 			auditBundle.BuildAt = inspectManifest.Created
@@ -74,19 +70,43 @@ func GetDataFromBundleImage(auditBundle *models.AuditBundle,
 			auditBundle.BuildAt = inspectManifest.DockerConfig.Labels["build-date"]
 		}
 
-		auditBundle.OCPLabel = inspectManifest.DockerConfig.Labels["com.redhat.openshift.versions"]
+		auditBundle.OCPLabel = inspectManifest.DockerConfig.Labels[pkg.OCPLabel]
+		auditBundle.BundleImageLabels = inspectManifest.DockerConfig.Labels
+		verifyBundleDigest(auditBundle, inspectManifest)
 	}
 
+	bundleDir := createBundleDir(auditBundle)
+	extractBundleFromImage(auditBundle, bundleDir, inspectManifest)
+
+	auditBundle = GetDataFromBundleDir(auditBundle, filepath.Join(bundleDir, "bundle"),
+		disableScorecard, disableValidators)
+
+	cleanupBundleDir(auditBundle, bundleDir, serverMode)
+
+	return auditBundle
+}
+
+// GetDataFromBundleDir returns the bundle from the directory informed which has its manifests and metadata.
+// Note that the scorecard config is written in the directory
+func GetDataFromBundleDir(auditBundle *models.AuditBundle,
+	dir string, disableScorecard, disableValidators bool) *models.AuditBundle {
+
 	// Read the bundle
-	auditBundle.Bundle, err = apimanifests.GetBundleFromDir(filepath.Join(bundleDir, "bundle"))
+	var err error
+	auditBundle.Bundle, err = apimanifests.GetBundleFromDir(dir)
 	if err != nil {
 		auditBundle.Errors = append(auditBundle.Errors, fmt.Errorf("unable to get the bundle: %s", err).Error())
 		return auditBundle
 	}
 
+	auditBundle.BundleAnnotations, err = readBundleAnnotations(dir)
+	if err != nil {
+		auditBundle.Errors = append(auditBundle.Errors, err.Error())
+	}
+
 	// Gathering data from scorecard
 	if !disableScorecard {
-		auditBundle = RunScorecard(filepath.Join(bundleDir, "bundle"), auditBundle)
+		auditBundle = RunScorecard(dir, auditBundle)
 	}
 
 	// Run validators
@@ -95,11 +115,44 @@ func GetDataFromBundleImage(auditBundle *models.AuditBundle,
 
 	}
 
-	cleanupBundleDir(auditBundle, bundleDir, serverMode)
-
 	return auditBundle
 }
 
+// AddDataFromAnnotations sets the package and channels of the bundle from its metadata/annotations.yaml.
+// It is used when the bundle is audit without an index, where this data would be obtained from the index db
+func AddDataFromAnnotations(auditBundle *models.AuditBundle) {
+	if auditBundle.Bundle != nil && auditBundle.Bundle.CSV != nil {
+		auditBundle.OperatorBundleName = auditBundle.Bundle.CSV.Name
+	}
+	if len(auditBundle.BundleAnnotations) == 0 {
+		return
+	}
+	auditBundle.PackageName = auditBundle.BundleAnnotations[packageAnnotation]
+	auditBundle.DefaultChannel = auditBundle.BundleAnnotations[defaultChannelAnnotation]
+	for _, v := range strings.Split(auditBundle.BundleAnnotations[channelsAnnotation], ",") {
+		if len(strings.TrimSpace(v)) > 0 {
+			auditBundle.Channels = append(auditBundle.Channels, strings.TrimSpace(v))
+		}
+	}
+}
+
+// readBundleAnnotations returns the annotations from the metadata/annotations.yaml of the bundle
+func readBundleAnnotations(dir string) (map[string]string, error) {
+	annotationsPath := filepath.Join(dir, "metadata", "annotations.yaml")
+	if _, err := os.Stat(annotationsPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	annFile, err := pkg.ReadFile(annotationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read annotations.yaml: %s", err)
+	}
+	var bundleAnnotations BundleAnnotations
+	if err := yaml.Unmarshal(annFile, &bundleAnnotations); err != nil {
+		return nil, fmt.Errorf("unable to Unmarshal annotations.yaml: %s", err)
+	}
+	return bundleAnnotations.Annotations, nil
+}
+
 func createBundleDir(auditBundle *models.AuditBundle) string {
 	dir := fmt.Sprintf("./tmp/%s", auditBundle.OperatorBundleName)
 	cmd := exec.Command("mkdir", dir)
@@ -111,8 +164,31 @@ func createBundleDir(auditBundle *models.AuditBundle) string {
 	return dir
 }
 
-func extractBundleFromImage(auditBundle *models.AuditBundle, bundleDir string) {
+// verifyBundleDigest sets the digest of the bundle image pulled and checks that it is the digest
+// of the bundle path when the bundle is referenced by digest
+func verifyBundleDigest(auditBundle *models.AuditBundle, inspect pkg.DockerInspectManifest) {
+	repository, reference := pkg.SplitImageTag(auditBundle.OperatorBundleImagePath)
+	if !pkg.IsDigest(reference) {
+		auditBundle.BundleImageDigest = pkg.ImageDigest(repository, inspect)
+		return
+	}
+	if !pkg.HasRepoDigest(inspect, repository, reference) {
+		auditBundle.BundleImageDigest = pkg.ImageDigest(repository, inspect)
+		auditBundle.Errors = append(auditBundle.Errors,
+			fmt.Errorf("the digest of the bundle image pulled (%s) does not match the digest of the bundle "+
+				"path (%s)", auditBundle.BundleImageDigest, reference).Error())
+		return
+	}
+	auditBundle.BundleImageDigest = reference
+}
+
+// extractBundleFromImage saves the image pulled by its ID, since the images pulled by digest
+// cannot be saved by their reference, and extracts its layers in the bundleDir
+func extractBundleFromImage(auditBundle *models.AuditBundle, bundleDir string, inspect pkg.DockerInspectManifest) {
 	imageName := strings.Split(auditBundle.OperatorBundleImagePath, "@")[0]
+	if len(inspect.ID) > 0 {
+		imageName = inspect.ID
+	}
 	tarPath := fmt.Sprintf("%s/%s.tar", bundleDir, auditBundle.OperatorBundleName)
 	cmd := exec.Command("docker", "save", imageName, "-o", tarPath)
 	_, err := pkg.RunCommand(cmd)
@@ -0,0 +1,75 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+func TestVerifyBundleDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		bundlePath string
+		inspect    pkg.DockerInspectManifest
+		wantDigest string
+		wantErrors []string
+	}{
+		{
+			name:       "should set the digest of the image pulled when the bundle is referenced by tag",
+			bundlePath: "quay.io/operatorhubio/etcd:v0.9.4",
+			inspect:    pkg.DockerInspectManifest{RepoDigests: []string{"quay.io/operatorhubio/etcd@sha256:9f1c2d"}},
+			wantDigest: "sha256:9f1c2d",
+		},
+		{
+			name:       "should keep the digest of the bundle path when it matches the image pulled",
+			bundlePath: "quay.io/operatorhubio/etcd@sha256:9f1c2d",
+			inspect:    pkg.DockerInspectManifest{RepoDigests: []string{"quay.io/operatorhubio/etcd@sha256:9f1c2d"}},
+			wantDigest: "sha256:9f1c2d",
+		},
+		{
+			name:       "should match the digest when another repository is listed first",
+			bundlePath: "quay.io/operatorhubio/etcd@sha256:9f1c2d",
+			inspect: pkg.DockerInspectManifest{RepoDigests: []string{
+				"registry.example.com/mirror/etcd@sha256:4a7b3e",
+				"quay.io/operatorhubio/etcd@sha256:9f1c2d",
+			}},
+			wantDigest: "sha256:9f1c2d",
+		},
+		{
+			name:       "should report when the digest of the bundle path does not match the image pulled",
+			bundlePath: "quay.io/operatorhubio/etcd@sha256:9f1c2d",
+			inspect:    pkg.DockerInspectManifest{RepoDigests: []string{"quay.io/operatorhubio/etcd@sha256:4a7b3e"}},
+			wantDigest: "sha256:4a7b3e",
+			wantErrors: []string{"the digest of the bundle image pulled (sha256:4a7b3e) does not match the digest " +
+				"of the bundle path (sha256:9f1c2d)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditBundle := models.AuditBundle{OperatorBundleImagePath: tt.bundlePath}
+			verifyBundleDigest(&auditBundle, tt.inspect)
+			if auditBundle.BundleImageDigest != tt.wantDigest {
+				t.Errorf("verifyBundleDigest() digest = %s, want %s", auditBundle.BundleImageDigest, tt.wantDigest)
+			}
+			if !reflect.DeepEqual(auditBundle.Errors, tt.wantErrors) {
+				t.Errorf("verifyBundleDigest() errors = %v, want %v", auditBundle.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+// skopeoInspect defines the data used from the output of skopeo inspect
+type skopeoInspect struct {
+	Labels map[string]string `json:"Labels"`
+}
+
+// GetImageLabels returns the labels of the image by fetching only its manifest and config with skopeo,
+// which avoids pulling the image layers. If skopeo is not available then the image is pulled and inspected.
+func GetImageLabels(image string) (map[string]string, error) {
+	cmd := exec.Command("skopeo", "inspect", "--no-tags", fmt.Sprintf("docker://%s", image))
+	output, err := pkg.RunCommand(cmd)
+	if err == nil {
+		var inspect skopeoInspect
+		if err := json.Unmarshal(output, &inspect); err == nil {
+			return inspect.Labels, nil
+		}
+	}
+	log.Debugf("unable to inspect the image %s with skopeo, the image will be pulled : %s", image, err)
+
+	if err := DownloadImage(image); err != nil {
+		return nil, fmt.Errorf("unable to download container image (%s): %s", image, err)
+	}
+	inspectManifest, err := pkg.RunDockerInspect(image)
+	if err != nil {
+		return nil, fmt.Errorf("unable to inspect container image (%s): %s", image, err)
+	}
+	return inspectManifest.DockerConfig.Labels, nil
+}
+
+// MatchBundleLabels returns true when the labels of the bundle image match any of the selectors.
+// It should be called before GetDataFromBundleImage to skip the bundles which should not be audit.
+// When the labels cannot be checked, the bundle is kept in the report with the audit error.
+func MatchBundleLabels(auditBundle *models.AuditBundle, selectors []labels.Selector) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	if len(auditBundle.OperatorBundleImagePath) < 1 {
+		return false
+	}
+	imageLabels, err := GetImageLabels(auditBundle.OperatorBundleImagePath)
+	if err != nil {
+		auditBundle.Errors = append(auditBundle.Errors,
+			fmt.Errorf("unable to check the labels of the bundle image : %s", err).Error())
+		return true
+	}
+	return pkg.MatchLabelSelectors(selectors, imageLabels)
+}
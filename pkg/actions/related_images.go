@@ -0,0 +1,170 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+const relatedImagesSource = "relatedImages"
+const relatedImageEnvPrefix = "RELATED_IMAGE_"
+
+// imageRegex matches the values of the env vars which are image references with their registry,
+// e.g. quay.io/example/operand:v1.0.0 or registry.example.com:5000/operand@sha256:...
+var imageRegex = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)*(:[0-9]+)?/[a-z0-9._/-]+` +
+	`(:[\w.-]+|@sha256:[a-f0-9]{64})$`)
+
+// skopeoPlatforms defines the data used from the raw manifest (list) returned by skopeo
+type skopeoPlatforms struct {
+	Manifests []struct {
+		Platform struct {
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// skopeoArchitecture defines the data used from the output of skopeo inspect of single arch images
+type skopeoArchitecture struct {
+	Architecture string `json:"Architecture"`
+}
+
+// AuditRelatedImages sets the images referenced by the bundle in the spec.relatedImages of its CSV, in the
+// containers of its deployments and in their env vars. If check is true then the images are inspected to
+// verify that they exist and which architectures they provide. When a registry is informed the images are
+// checked in it instead of in their registries, as they would be pulled from a mirror in disconnected envs.
+func AuditRelatedImages(auditBundle *models.AuditBundle, check bool, registry string) {
+	csv := auditBundle.CSVFromIndexDB
+	if auditBundle.Bundle != nil && auditBundle.Bundle.CSV != nil {
+		csv = auditBundle.Bundle.CSV
+	}
+	auditBundle.RelatedImages = GetRelatedImages(csv)
+	if !check {
+		return
+	}
+	for i, v := range auditBundle.RelatedImages {
+		image := v.Image
+		if len(registry) > 0 {
+			image = MirrorImage(image, registry)
+		}
+		architectures, err := GetImageArchitectures(image)
+		if err != nil {
+			log.Errorf("unable to check the related image %s of the bundle %s : %s",
+				image, auditBundle.OperatorBundleName, err)
+			auditBundle.RelatedImages[i].Error = fmt.Sprintf("unable to inspect %s", image)
+			continue
+		}
+		auditBundle.RelatedImages[i].Architectures = architectures
+	}
+}
+
+// GetRelatedImages returns the images referenced by the CSV sorted by their names
+func GetRelatedImages(csv *v1alpha1.ClusterServiceVersion) []models.RelatedImage {
+	if csv == nil {
+		return nil
+	}
+	images := map[string]*models.RelatedImage{}
+	add := func(image, source string) {
+		image = strings.TrimSpace(image)
+		if len(image) == 0 {
+			return
+		}
+		if _, found := images[image]; !found {
+			_, reference := pkg.SplitImageTag(image)
+			images[image] = &models.RelatedImage{Image: image, Pinned: pkg.IsDigest(reference)}
+		}
+		images[image].Sources = append(images[image].Sources, source)
+		if source == relatedImagesSource {
+			images[image].InRelatedImages = true
+		}
+	}
+
+	for _, v := range csv.Spec.RelatedImages {
+		add(v.Image, relatedImagesSource)
+	}
+	for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		podSpec := deployment.Spec.Template.Spec
+		containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+		for _, c := range containers {
+			add(c.Image, fmt.Sprintf("container:%s", c.Name))
+			for _, env := range c.Env {
+				if strings.HasPrefix(env.Name, relatedImageEnvPrefix) || imageRegex.MatchString(env.Value) {
+					add(env.Value, fmt.Sprintf("env:%s", env.Name))
+				}
+			}
+		}
+	}
+
+	result := make([]models.RelatedImage, 0, len(images))
+	for _, v := range images {
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Image < result[j].Image
+	})
+	return result
+}
+
+// MirrorImage returns the image in the registry informed, e.g. quay.io/example/operand:v1 returns
+// localhost:5000/example/operand:v1 for the registry localhost:5000
+func MirrorImage(image, registry string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(registry, "/"), parts[1])
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(registry, "/"), image)
+}
+
+// GetImageArchitectures returns the architectures provided by the image by fetching only its manifest
+// with skopeo. The images without a manifest list provide the architecture of their config.
+func GetImageArchitectures(image string) ([]string, error) {
+	cmd := exec.Command("skopeo", "inspect", "--raw", fmt.Sprintf("docker://%s", image))
+	output, err := pkg.RunCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var platforms skopeoPlatforms
+	if err := json.Unmarshal(output, &platforms); err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest of %s : %s", image, err)
+	}
+	var architectures []string
+	for _, v := range platforms.Manifests {
+		architectures = append(architectures, v.Platform.Architecture)
+	}
+	if len(architectures) > 0 {
+		return pkg.GetUniqueValues(architectures), nil
+	}
+
+	cmd = exec.Command("skopeo", "inspect", "--no-tags", fmt.Sprintf("docker://%s", image))
+	if output, err = pkg.RunCommand(cmd); err != nil {
+		return nil, err
+	}
+	var inspect skopeoArchitecture
+	if err := json.Unmarshal(output, &inspect); err != nil {
+		return nil, fmt.Errorf("unable to parse the inspect of %s : %s", image, err)
+	}
+	return []string{inspect.Architecture}, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+const operandDigest = "quay.io/example/operand@sha256:" +
+	"4b5f3a1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a"
+
+func csvWithImages(relatedImages []v1alpha1.RelatedImage,
+	containers ...corev1.Container) *v1alpha1.ClusterServiceVersion {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.Spec.RelatedImages = relatedImages
+	deployment := v1alpha1.StrategyDeploymentSpec{Name: "operator"}
+	deployment.Spec.Template.Spec.Containers = containers
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []v1alpha1.StrategyDeploymentSpec{deployment}
+	return csv
+}
+
+func TestGetRelatedImages(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  *v1alpha1.ClusterServiceVersion
+		want []models.RelatedImage
+	}{
+		{
+			name: "should return nil without csv",
+		},
+		{
+			name: "should merge the sources of the images",
+			csv: csvWithImages([]v1alpha1.RelatedImage{{Name: "operand", Image: operandDigest}},
+				corev1.Container{Name: "manager", Image: "quay.io/example/operator:v1.0.0",
+					Env: []corev1.EnvVar{{Name: "RELATED_IMAGE_OPERAND", Value: operandDigest}}}),
+			want: []models.RelatedImage{
+				{Image: operandDigest, Sources: []string{"relatedImages", "env:RELATED_IMAGE_OPERAND"},
+					Pinned: true, InRelatedImages: true},
+				{Image: "quay.io/example/operator:v1.0.0", Sources: []string{"container:manager"}},
+			},
+		},
+		{
+			name: "should only return the env vars with images",
+			csv: csvWithImages(nil,
+				corev1.Container{Name: "manager", Image: operandDigest,
+					Env: []corev1.EnvVar{{Name: "WATCH_NAMESPACE", Value: "default"},
+						{Name: "AGENT_IMAGE", Value: "registry.example.com:5000/agent:latest"}}}),
+			want: []models.RelatedImage{
+				{Image: operandDigest, Sources: []string{"container:manager"}, Pinned: true},
+				{Image: "registry.example.com:5000/agent:latest", Sources: []string{"env:AGENT_IMAGE"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRelatedImages(tt.csv); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRelatedImages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		registry string
+		want     string
+	}{
+		{
+			name:     "should replace the registry",
+			image:    "quay.io/example/operand:v1",
+			registry: "localhost:5000",
+			want:     "localhost:5000/example/operand:v1",
+		},
+		{
+			name:     "should keep the repository of the images without registry",
+			image:    "example/operand:v1",
+			registry: "localhost:5000/",
+			want:     "localhost:5000/example/operand:v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MirrorImage(tt.image, tt.registry); got != tt.want {
+				t.Errorf("MirrorImage() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
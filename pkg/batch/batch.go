@@ -0,0 +1,212 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batch audits the catalogs defined in a config file and reports the results in a run manifest.
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/catalogs"
+)
+
+// Options defines how the batch is run
+type Options struct {
+	Config *catalogs.Config
+	// Binary is the audit-tool used to generate the reports
+	Binary string
+	// Parallel is the number of audits which run at the same time
+	Parallel int
+	// WorkDir is the dir where a working dir is created per worker, since the audit-tool uses the ./tmp
+	// and ./output dirs
+	WorkDir string
+}
+
+// Manifest describes a batch run and the result of each audit done
+type Manifest struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Duration   string    `json:"duration"`
+	Jobs       []Job     `json:"jobs"`
+}
+
+// Job is the audit of a report kind for an image of a catalog
+type Job struct {
+	Catalog    string    `json:"catalog"`
+	Image      string    `json:"image"`
+	Kind       string    `json:"kind"`
+	OutputPath string    `json:"outputPath"`
+	Files      []string  `json:"files,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Duration   string    `json:"duration,omitempty"`
+	Error      string    `json:"error,omitempty"`
+
+	args []string
+}
+
+// Failed returns the number of jobs which failed
+func (m Manifest) Failed() int {
+	failed := 0
+	for _, j := range m.Jobs {
+		if len(j.Error) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Write outputs the manifest as JSON in the path informed
+func (m Manifest) Write(path string) error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Jobs returns the audits which should be done for the config, in the order that the catalogs,
+// images and kinds are informed
+func Jobs(config *catalogs.Config) []Job {
+	var jobs []Job
+	for _, c := range config.Catalogs {
+		outputPath := c.GetOutputPath(config)
+		for _, image := range c.Images() {
+			for _, kind := range c.GetKinds() {
+				jobs = append(jobs, Job{
+					Catalog:    c.Name,
+					Image:      image,
+					Kind:       kind,
+					OutputPath: outputPath,
+					args:       c.Args(kind, image, outputPath, c.GetOutput(config)),
+				})
+			}
+		}
+	}
+	return jobs
+}
+
+// Run audits all catalogs of the config and returns the manifest with the results
+func Run(options Options) Manifest {
+	manifest := Manifest{StartedAt: time.Now().UTC(), Jobs: Jobs(options.Config)}
+	login(options.Config)
+
+	parallel := options.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	if parallel > 1 {
+		// the audits which run at the same time may use the same bundle images, which should not be removed
+		for i := range manifest.Jobs {
+			manifest.Jobs[i].keepImages()
+		}
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		workDir := filepath.Join(options.WorkDir, fmt.Sprintf("worker-%d", i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range queue {
+				// each job is only changed by the worker which runs it
+				runJob(&manifest.Jobs[index], options.Binary, workDir)
+			}
+		}()
+	}
+	for i := range manifest.Jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	manifest.FinishedAt = time.Now().UTC()
+	manifest.Duration = manifest.FinishedAt.Sub(manifest.StartedAt).String()
+	return manifest
+}
+
+// login runs docker login once for each registry informed in the catalogs
+func login(config *catalogs.Config) {
+	done := map[string]bool{}
+	for _, c := range config.Catalogs {
+		if len(c.Registry) == 0 || done[c.Registry] {
+			continue
+		}
+		done[c.Registry] = true
+		if _, err := pkg.RunCommand(exec.Command("docker", "login", c.Registry)); err != nil {
+			log.Errorf("unable to login in the registry %s : %s", c.Registry, err)
+		}
+	}
+}
+
+// keepImages adds the --server-mode flag to the jobs which support it, so that the bundle images are not removed
+func (j *Job) keepImages() {
+	if j.Kind == "channels" {
+		return
+	}
+	for _, v := range j.args {
+		if v == "--server-mode" {
+			return
+		}
+	}
+	j.args = append(j.args, "--server-mode")
+}
+
+func runJob(job *Job, binary, workDir string) {
+	log.Infof("Starting the %s audit of %s", job.Kind, job.Image)
+	job.StartedAt = time.Now().UTC()
+	defer func() {
+		job.FinishedAt = time.Now().UTC()
+		job.Duration = job.FinishedAt.Sub(job.StartedAt).String()
+	}()
+
+	for _, dir := range []string{workDir, job.OutputPath} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			job.Error = fmt.Sprintf("unable to create the dir %s : %s", dir, err)
+			log.Error(job.Error)
+			return
+		}
+	}
+
+	cmd := exec.Command(binary, job.args...)
+	cmd.Dir = workDir
+	if output, err := pkg.RunCommand(cmd); err != nil {
+		log.Errorf("unable to audit the %s of %s : %s", job.Kind, job.Image, err)
+		// the last line of the output has the error logged by the audit-tool
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		job.Error = lines[len(lines)-1]
+		if len(job.Error) == 0 {
+			job.Error = err.Error()
+		}
+	}
+
+	// the reports are named with the kind, image and date which they were generated
+	files, err := filepath.Glob(filepath.Join(job.OutputPath, pkg.GetReportName(job.Image, job.Kind, "*")))
+	if err != nil {
+		log.Errorf("unable to find the reports of %s : %s", job.Image, err)
+	}
+	job.Files = files
+}
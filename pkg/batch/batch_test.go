@@ -0,0 +1,103 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/audit/pkg/catalogs"
+)
+
+func TestJobs(t *testing.T) {
+	config := &catalogs.Config{
+		OutputPath: "/reports",
+		Output:     "all",
+		Catalogs: []catalogs.Catalog{
+			{
+				Name:             "redhat",
+				Image:            "registry.redhat.io/redhat/redhat-operator-index:v4.8",
+				Tags:             []string{"v4.7", "v4.6"},
+				Kinds:            []string{"bundles", "channels"},
+				HeadOnly:         true,
+				DisableScorecard: true,
+			},
+			{
+				Name:       "local",
+				Image:      "localhost:5000/catalog:latest",
+				OutputPath: "/local",
+				Output:     "json",
+				Limit:      3,
+			},
+		},
+	}
+
+	want := []Job{
+		{Catalog: "redhat", Image: "registry.redhat.io/redhat/redhat-operator-index:v4.7", Kind: "bundles",
+			OutputPath: "/reports/redhat", args: []string{"index", "bundles",
+				"--index-image=registry.redhat.io/redhat/redhat-operator-index:v4.7", "--output-path=/reports/redhat",
+				"--output=all", "--head-only", "--disable-scorecard"}},
+		{Catalog: "redhat", Image: "registry.redhat.io/redhat/redhat-operator-index:v4.7", Kind: "channels",
+			OutputPath: "/reports/redhat", args: []string{"index", "channels",
+				"--index-image=registry.redhat.io/redhat/redhat-operator-index:v4.7", "--output-path=/reports/redhat",
+				"--output=all"}},
+		{Catalog: "redhat", Image: "registry.redhat.io/redhat/redhat-operator-index:v4.6", Kind: "bundles",
+			OutputPath: "/reports/redhat", args: []string{"index", "bundles",
+				"--index-image=registry.redhat.io/redhat/redhat-operator-index:v4.6", "--output-path=/reports/redhat",
+				"--output=all", "--head-only", "--disable-scorecard"}},
+		{Catalog: "redhat", Image: "registry.redhat.io/redhat/redhat-operator-index:v4.6", Kind: "channels",
+			OutputPath: "/reports/redhat", args: []string{"index", "channels",
+				"--index-image=registry.redhat.io/redhat/redhat-operator-index:v4.6", "--output-path=/reports/redhat",
+				"--output=all"}},
+		{Catalog: "local", Image: "localhost:5000/catalog:latest", Kind: "bundles",
+			OutputPath: "/local", args: []string{"index", "bundles",
+				"--index-image=localhost:5000/catalog:latest", "--output-path=/local", "--output=json", "--limit=3"}},
+	}
+	if got := Jobs(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("Jobs() got = %v, want %v", got, want)
+	}
+}
+
+func TestJobKeepImages(t *testing.T) {
+	tests := []struct {
+		name string
+		job  Job
+		want []string
+	}{
+		{
+			name: "should add the server mode flag",
+			job:  Job{Kind: "bundles", args: []string{"index", "bundles"}},
+			want: []string{"index", "bundles", "--server-mode"},
+		},
+		{
+			name: "should not add the server mode flag twice",
+			job:  Job{Kind: "packages", args: []string{"index", "packages", "--server-mode"}},
+			want: []string{"index", "packages", "--server-mode"},
+		},
+		{
+			name: "should not add the server mode flag to the channels",
+			job:  Job{Kind: "channels", args: []string{"index", "channels"}},
+			want: []string{"index", "channels"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.job.keepImages()
+			if !reflect.DeepEqual(tt.job.args, tt.want) {
+				t.Errorf("keepImages() = %v, want %v", tt.job.args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package catalogs provides the configuration of the index catalogs which are audit periodically.
+package catalogs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/writers"
+)
+
+const defaultInterval = 24 * time.Hour
+
+// Kinds are the index reports which can be generated for the catalogs
+var Kinds = []string{"bundles", "packages", "channels"}
+
+// Config defines the catalogs.yaml file, e.g.:
+//
+//	interval: 24h
+//	catalogs:
+//	- name: redhat-operator-index-v4.8
+//	  image: registry.redhat.io/redhat/redhat-operator-index:v4.8
+//	  headOnly: true
+//	  disableScorecard: true
+type Config struct {
+	// Interval defines how often the catalogs are audit (e.g. 12h)
+	Interval string `yaml:"interval,omitempty"`
+	// OutputPath defines the dir where the batch command outputs the reports of each catalog in a sub-dir
+	OutputPath string `yaml:"outputPath,omitempty"`
+	// Output defines the formats of the reports generated by the batch command (e.g. json,xls)
+	Output   string    `yaml:"output,omitempty"`
+	Catalogs []Catalog `yaml:"catalogs"`
+}
+
+// Catalog defines an index catalog image and the options used to audit it
+type Catalog struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+	// Tags of the image which are audit by the batch command. When not informed the image is audit as it is
+	Tags []string `yaml:"tags,omitempty"`
+	// Kinds of the reports generated by the batch command. Default: bundles
+	Kinds []string `yaml:"kinds,omitempty"`
+	// Registry which the batch command logs in via docker login before audit the catalog
+	Registry string `yaml:"registry,omitempty"`
+	// OutputPath overwrites the dir where the batch command outputs the reports of the catalog
+	OutputPath        string `yaml:"outputPath,omitempty"`
+	Output            string `yaml:"output,omitempty"`
+	Filter            string `yaml:"filter,omitempty"`
+	Limit             int32  `yaml:"limit,omitempty"`
+	HeadOnly          bool   `yaml:"headOnly,omitempty"`
+	DisableScorecard  bool   `yaml:"disableScorecard,omitempty"`
+	DisableValidators bool   `yaml:"disableValidators,omitempty"`
+	ServerMode        bool   `yaml:"serverMode,omitempty"`
+	// CheckRelatedImages and RelatedImagesRegistry are only used to audit the bundles
+	CheckRelatedImages    bool   `yaml:"checkRelatedImages,omitempty"`
+	RelatedImagesRegistry string `yaml:"relatedImagesRegistry,omitempty"`
+}
+
+// LoadConfig reads and validates the config file informed
+func LoadConfig(path string) (*Config, error) {
+	data, err := pkg.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the config %s : %s", path, err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse the config %s : %s", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s : %s", path, err)
+	}
+	return &config, nil
+}
+
+// Validate returns an error when the config has no catalogs or they are not valid
+func (c *Config) Validate() error {
+	if len(c.Catalogs) == 0 {
+		return fmt.Errorf("no catalogs were informed")
+	}
+	names := map[string]bool{}
+	for _, v := range c.Catalogs {
+		if len(v.Name) == 0 || len(v.Image) == 0 {
+			return fmt.Errorf("the name and image are required for all catalogs")
+		}
+		if names[v.Name] {
+			return fmt.Errorf("the catalog name %s is duplicated", v.Name)
+		}
+		names[v.Name] = true
+		for _, kind := range v.Kinds {
+			if !isKind(kind) {
+				return fmt.Errorf("invalid kind %s for the catalog %s. The available options are: %s",
+					kind, v.Name, strings.Join(Kinds, ", "))
+			}
+		}
+		if v.Limit < 0 {
+			return fmt.Errorf("invalid limit %v for the catalog %s", v.Limit, v.Name)
+		}
+		if len(v.Output) > 0 {
+			if _, err := writers.ParseFormats(v.Output); err != nil {
+				return fmt.Errorf("invalid output for the catalog %s : %s", v.Name, err)
+			}
+		}
+	}
+	if len(c.Output) > 0 {
+		if _, err := writers.ParseFormats(c.Output); err != nil {
+			return fmt.Errorf("invalid output : %s", err)
+		}
+	}
+	if _, err := c.GetInterval(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetInterval returns the interval between the audits or the default of 24h when it is not informed
+func (c *Config) GetInterval() (time.Duration, error) {
+	if len(c.Interval) == 0 {
+		return defaultInterval, nil
+	}
+	interval, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %s : %s", c.Interval, err)
+	}
+	return interval, nil
+}
+
+// Find returns the catalog with the name informed
+func (c *Config) Find(name string) (Catalog, bool) {
+	for _, v := range c.Catalogs {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Catalog{}, false
+}
+
+// Images returns the images of the catalog with each tag informed
+func (c Catalog) Images() []string {
+	if len(c.Tags) == 0 {
+		return []string{c.Image}
+	}
+	repository, _ := pkg.SplitImageTag(c.Image)
+	var images []string
+	for _, tag := range c.Tags {
+		images = append(images, fmt.Sprintf("%s:%s", repository, tag))
+	}
+	return images
+}
+
+// GetKinds returns the kinds of the reports generated by the batch command or bundles when they are not informed
+func (c Catalog) GetKinds() []string {
+	if len(c.Kinds) == 0 {
+		return []string{"bundles"}
+	}
+	return c.Kinds
+}
+
+// GetOutputPath returns the dir where the batch command outputs the reports of the catalog
+func (c Catalog) GetOutputPath(config *Config) string {
+	if len(c.OutputPath) > 0 {
+		return c.OutputPath
+	}
+	return filepath.Join(config.OutputPath, c.Name)
+}
+
+// GetOutput returns the formats of the reports generated by the batch command. Default: json
+func (c Catalog) GetOutput(config *Config) string {
+	if len(c.Output) > 0 {
+		return c.Output
+	}
+	if len(config.Output) > 0 {
+		return config.Output
+	}
+	return pkg.JSON
+}
+
+// Args returns the arguments of the audit-tool to generate the report kind informed for the image
+// of the catalog. Note that only the flags supported by the report kind are added
+func (c Catalog) Args(kind, image, outputPath, output string) []string {
+	args := []string{"index", kind,
+		fmt.Sprintf("--index-image=%s", image),
+		fmt.Sprintf("--output-path=%s", outputPath),
+		fmt.Sprintf("--output=%s", output),
+	}
+	if len(c.Filter) > 0 {
+		args = append(args, fmt.Sprintf("--filter=%s", c.Filter))
+	}
+	if c.Limit > 0 {
+		args = append(args, fmt.Sprintf("--limit=%v", c.Limit))
+	}
+	if kind == "channels" {
+		return args
+	}
+	if kind == "bundles" {
+		if c.HeadOnly {
+			args = append(args, "--head-only")
+		}
+		if c.CheckRelatedImages {
+			args = append(args, "--check-related-images")
+		}
+		if len(c.RelatedImagesRegistry) > 0 {
+			args = append(args, fmt.Sprintf("--related-images-registry=%s", c.RelatedImagesRegistry))
+		}
+	}
+	if c.DisableScorecard {
+		args = append(args, "--disable-scorecard")
+	}
+	if c.DisableValidators {
+		args = append(args, "--disable-validators")
+	}
+	if c.ServerMode {
+		args = append(args, "--server-mode")
+	}
+	return args
+}
+
+func isKind(kind string) bool {
+	for _, v := range Kinds {
+		if v == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+const sortDescSuffix = ":desc"
+
+// JSONFieldNames returns the JSON names of the fields of the struct informed
+func JSONFieldNames(v interface{}) []string {
+	var names []string
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonFieldName(t.Field(i)); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if len(name) == 0 {
+		return field.Name
+	}
+	return name
+}
+
+// ValidateJSONFieldNames returns an error when a name informed is not a JSON field of the struct
+func ValidateJSONFieldNames(v interface{}, names []string) error {
+	valid := JSONFieldNames(v)
+	for _, name := range names {
+		found := false
+		for _, n := range valid {
+			if n == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not a valid field. The available options are: %s",
+				name, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// SelectJSONFields returns the JSON of the value informed with only the fields selected and in the
+// same order. Note that the fields which are empty and are omitted (omitempty) will not be in the result
+func SelectJSONFields(v interface{}, fields []string) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	first := true
+	for _, f := range fields {
+		value, found := all[f]
+		if !found {
+			continue
+		}
+		if !first {
+			buf.WriteString(",")
+		}
+		first = false
+		key, _ := json.Marshal(f)
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// ParseSortBy returns the field and if the order is descending from values such as bundleVersion:desc
+func ParseSortBy(value string) (string, bool) {
+	if strings.HasSuffix(value, sortDescSuffix) {
+		return strings.TrimSuffix(value, sortDescSuffix), true
+	}
+	return value, false
+}
+
+// SortByJSONField sorts the slice of structs informed by the field which has the JSON name informed.
+// The strings are compared as dates or semantic versions when all values of the field can be parsed as such,
+// which allows sort by the build date, bundle version or SDK version. The empty values are the lowest ones.
+func SortByJSONField(slice interface{}, field string, desc bool) error {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("unable to sort %s, since it is not a slice", v.Kind())
+	}
+	if v.Len() == 0 {
+		return nil
+	}
+
+	index := -1
+	t := v.Type().Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if jsonFieldName(t.Field(i)) == field {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("unable to sort by %s, since it is not a valid field", field)
+	}
+
+	compare := compareValues
+	if t.Field(index).Type.Kind() == reflect.String {
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, v.Index(i).Field(index).String())
+		}
+		compareStrings := stringComparator(values)
+		compare = func(a, b reflect.Value) int {
+			return compareStrings(a.String(), b.String())
+		}
+	}
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		a := v.Index(i).Field(index)
+		b := v.Index(j).Field(index)
+		if desc {
+			return compare(b, a) < 0
+		}
+		return compare(a, b) < 0
+	})
+	return nil
+}
+
+// compareValues returns -1, 0 or 1 when a is lower, equal or greater than b
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		if !a.Bool() {
+			return -1
+		}
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInts(a.Int(), b.Int())
+	case reflect.Float32, reflect.Float64:
+		if a.Float() == b.Float() {
+			return 0
+		}
+		if a.Float() < b.Float() {
+			return -1
+		}
+		return 1
+	case reflect.Slice, reflect.Map:
+		return compareInts(int64(a.Len()), int64(b.Len()))
+	default:
+		return strings.Compare(fmt.Sprintf("%v", a.Interface()), fmt.Sprintf("%v", b.Interface()))
+	}
+}
+
+func compareInts(a, b int64) int {
+	if a == b {
+		return 0
+	}
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// stringComparator returns the function used to compare all values of a field, which compares them as dates
+// or semantic versions when all values which are not empty can be parsed as such. Using the same comparison
+// for all values ensures that the order is consistent.
+func stringComparator(values []string) func(a, b string) int {
+	allDates, allVersions := true, true
+	for _, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+			allDates = false
+		}
+		if _, err := semver.ParseTolerant(v); err != nil {
+			allVersions = false
+		}
+	}
+
+	parsed := func(compare func(a, b string) int) func(a, b string) int {
+		return func(a, b string) int {
+			switch {
+			case len(a) == 0 && len(b) == 0:
+				return 0
+			case len(a) == 0:
+				return -1
+			case len(b) == 0:
+				return 1
+			}
+			return compare(a, b)
+		}
+	}
+	switch {
+	case allDates:
+		return parsed(func(a, b string) int {
+			dateA, _ := time.Parse(time.RFC3339Nano, a)
+			dateB, _ := time.Parse(time.RFC3339Nano, b)
+			return compareInts(dateA.UnixNano(), dateB.UnixNano())
+		})
+	case allVersions:
+		return parsed(func(a, b string) int {
+			verA, _ := semver.ParseTolerant(a)
+			verB, _ := semver.ParseTolerant(b)
+			return verA.Compare(verB)
+		})
+	}
+	return strings.Compare
+}
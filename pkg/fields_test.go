@@ -0,0 +1,130 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+type sortRow struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Date    string `json:"date,omitempty"`
+}
+
+func TestSortByJSONField(t *testing.T) {
+	rows := []sortRow{
+		{Name: "b", Version: "v1.10.0", Date: "2021-04-20T10:00:00Z"},
+		{Name: "a", Version: "1.9.1", Date: "2021-05-01T10:00:00Z"},
+		{Name: "c", Version: "0.2.0", Date: "2021-01-01T10:00:00Z"},
+	}
+	tests := []struct {
+		name    string
+		sortBy  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "should sort by name",
+			sortBy: "name",
+			want:   []string{"a", "b", "c"},
+		},
+		{
+			name:   "should sort by semantic version",
+			sortBy: "version",
+			want:   []string{"c", "a", "b"},
+		},
+		{
+			name:   "should sort by date in descending order",
+			sortBy: "date:desc",
+			want:   []string{"a", "b", "c"},
+		},
+		{
+			name:    "should fail for an invalid field",
+			sortBy:  "invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]sortRow{}, rows...)
+			field, desc := ParseSortBy(tt.sortBy)
+			err := SortByJSONField(sorted, field, desc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SortByJSONField() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []string
+			for _, v := range sorted {
+				got = append(got, v.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortByJSONField() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByJSONFieldMixedValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     []string
+	}{
+		{
+			name:     "should sort as semantic versions with the empty values first",
+			versions: []string{"v1.10.0", "", "1.9.1"},
+			want:     []string{"", "1.9.1", "v1.10.0"},
+		},
+		{
+			name:     "should sort all values as strings when any of them is not a semantic version",
+			versions: []string{"v1.10.0", "latest", "1.9.1", "v1.2.0"},
+			want:     []string{"1.9.1", "latest", "v1.10.0", "v1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []sortRow
+			for _, v := range tt.versions {
+				rows = append(rows, sortRow{Version: v})
+			}
+			if err := SortByJSONField(rows, "version", false); err != nil {
+				t.Fatalf("SortByJSONField() error = %v", err)
+			}
+			var got []string
+			for _, v := range rows {
+				got = append(got, v.Version)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortByJSONField() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectJSONFields(t *testing.T) {
+	got, err := SelectJSONFields(sortRow{Name: "a", Version: "1.0.0"}, []string{"version", "date", "name"})
+	if err != nil {
+		t.Fatalf("SelectJSONFields() error = %v", err)
+	}
+	want := `{"version":"1.0.0","name":"a"}`
+	if string(got) != want {
+		t.Errorf("SelectJSONFields() got = %s, want %s", got, want)
+	}
+}
@@ -31,20 +31,27 @@ import (
 
 const JSON = "json"
 const Xls = "xls"
+const CSV = "csv"
+const Markdown = "md"
+const HTML = "html"
 const All = "all"
 const Yes = "YES"
 const No = "NO"
 const Unknown = "UNKNOWN"
 const NotUsed = "NOT USED"
 
-const TableFormat = `{
-    "table_name": "table",
+// tableFormat returns the format used to output the tables in the workbooks.
+// Note that the table name must be unique per workbook
+func tableFormat(name string) string {
+	return fmt.Sprintf(`{
+    "table_name": "%s",
     "table_style": "TableStyleMedium2",
     "show_first_column": true,
     "show_last_column": true,
     "show_row_stripes": false,
     "show_column_stripes": false
-}`
+}`, name)
+}
 
 // PropertiesAnnotation used to Unmarshal the JSON in the CSV annotation
 type PropertiesAnnotation struct {
@@ -135,13 +142,7 @@ func WriteJSON(data []byte, imageName, outputPath, typeName string) error {
 
 func GetReportName(imageName, typeName, typeFile string) string {
 	dt := time.Now().Format("2006-01-02")
-
-	//prepare image name to use as name of the file
-	name := strings.ReplaceAll(imageName, "/", "_")
-	name = strings.ReplaceAll(name, ":", "_")
-	name = strings.ReplaceAll(name, "-", "_")
-
-	return fmt.Sprintf("%s_%s_%s.%s", typeName, name, dt, typeFile)
+	return fmt.Sprintf("%s_%s_%s.%s", typeName, ImageFileName(imageName), dt, typeFile)
 }
 
 func GenerateTemporaryDirs() {
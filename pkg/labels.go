@@ -0,0 +1,79 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LabelSelectors returns the selectors used to filter the bundles by the labels of their images.
+// The label and labelValue are the values informed via the --label and --label-value flags which
+// match the images with the label and exactly the value informed, or only with the label when the
+// value is not informed. Note that their values (e.g. v4.6,v4.7 or =v4.7) are not always valid in the
+// label selectors, which is why they are not parsed as such
+func LabelSelectors(label, labelValue string, selectors []string) ([]labels.Selector, error) {
+	var result []labels.Selector
+	if len(label) > 0 {
+		result = append(result, exactLabelSelector{Selector: labels.Everything(), label: label, value: labelValue})
+	}
+	for _, v := range selectors {
+		selector, err := labels.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %s : %s", v, err)
+		}
+		result = append(result, selector)
+	}
+	return result, nil
+}
+
+// exactLabelSelector matches the labels which have the label informed with exactly the value informed
+type exactLabelSelector struct {
+	labels.Selector
+	label string
+	value string
+}
+
+// Matches returns true when the label exists and has exactly the value, if it was informed
+func (s exactLabelSelector) Matches(l labels.Labels) bool {
+	if !l.Has(s.label) {
+		return false
+	}
+	return len(s.value) == 0 || l.Get(s.label) == s.value
+}
+
+// Empty returns false since the selector always checks the label
+func (s exactLabelSelector) Empty() bool {
+	return false
+}
+
+// String returns the label and value informed in the format label=value
+func (s exactLabelSelector) String() string {
+	if len(s.value) == 0 {
+		return s.label
+	}
+	return fmt.Sprintf("%s=%s", s.label, s.value)
+}
+
+// MatchLabelSelectors returns true when the labels match any of the selectors informed
+func MatchLabelSelectors(selectors []labels.Selector, imageLabels map[string]string) bool {
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(imageLabels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+)
+
+func TestMatchLabelSelectors(t *testing.T) {
+	imageLabels := map[string]string{
+		"com.redhat.openshift.versions":                    "v4.7",
+		"operators.operatorframework.io.bundle.package.v1": "etcd",
+	}
+	tests := []struct {
+		name       string
+		label      string
+		labelValue string
+		selectors  []string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "should match the label and value informed",
+			label:      "com.redhat.openshift.versions",
+			labelValue: "v4.7",
+			want:       true,
+		},
+		{
+			name:  "should match when the label informed exists",
+			label: "com.redhat.openshift.versions",
+			want:  true,
+		},
+		{
+			name:      "should match the in operator",
+			selectors: []string{"com.redhat.openshift.versions in (v4.6,v4.7)"},
+			want:      true,
+		},
+		{
+			name:      "should not match the notin operator",
+			selectors: []string{"com.redhat.openshift.versions notin (v4.7)"},
+		},
+		{
+			name:      "should match any of the selectors",
+			selectors: []string{"com.redhat.delivery.backport", "operators.operatorframework.io.bundle.package.v1=etcd"},
+			want:      true,
+		},
+		{
+			name:      "should match when the label does not exist",
+			selectors: []string{"!com.redhat.delivery.backport"},
+			want:      true,
+		},
+		{
+			name:      "should fail for an invalid selector",
+			selectors: []string{"com.redhat.openshift.versions in v4.7"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selectors, err := LabelSelectors(tt.label, tt.labelValue, tt.selectors)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LabelSelectors() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := MatchLabelSelectors(selectors, imageLabels); got != tt.want {
+				t.Errorf("MatchLabelSelectors() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelSelectorsLegacyFlags(t *testing.T) {
+	const ocpLabel = "com.redhat.openshift.versions"
+	tests := []struct {
+		name       string
+		labelValue string
+		imageValue string
+		want       bool
+	}{
+		{
+			name:       "should match a list of versions exactly",
+			labelValue: "v4.6,v4.7",
+			imageValue: "v4.6,v4.7",
+			want:       true,
+		},
+		{
+			name:       "should not match a list of versions which differs",
+			labelValue: "v4.6,v4.7",
+			imageValue: "v4.6",
+		},
+		{
+			name:       "should match a single version exactly",
+			labelValue: "=v4.7",
+			imageValue: "=v4.7",
+			want:       true,
+		},
+		{
+			name:       "should not match the version without the equal sign",
+			labelValue: "=v4.7",
+			imageValue: "v4.7",
+		},
+		{
+			name:       "should match a range of versions exactly",
+			labelValue: "v4.6-v4.8",
+			imageValue: "v4.6-v4.8",
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selectors, err := LabelSelectors(ocpLabel, tt.labelValue, nil)
+			if err != nil {
+				t.Fatalf("LabelSelectors() error = %v", err)
+			}
+			if got := MatchLabelSelectors(selectors, map[string]string{ocpLabel: tt.imageValue}); got != tt.want {
+				t.Errorf("MatchLabelSelectors() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the schema of the JSON reports. It must be bumped when fields are
+// removed or their meaning changes, so that the consumers are able to check and migrate the reports
+const SchemaVersion = "v1"
+
+// LegacySchemaVersion is the version of the reports generated before the schema was versioned
+const LegacySchemaVersion = "v0"
+
+// Checks which can be enabled in the reports
+const (
+	CheckValidators = "validators"
+	CheckScorecard  = "scorecard"
+	// CheckRelatedImages is enabled when the images referenced by the bundles are inspected
+	CheckRelatedImages = "relatedImages"
+)
+
+// Build has the version of the audit-tool which is injected via the ldflags of the main package
+type Build struct {
+	Version   string `json:"version,omitempty"`
+	GitCommit string `json:"gitCommit,omitempty"`
+	BuildDate string `json:"buildDate,omitempty"`
+}
+
+// BuildInfo is the version of the audit-tool running
+var BuildInfo Build
+
+// ReportMetadata describes the report, so that it can be consumed without parsing its file name
+type ReportMetadata struct {
+	// SchemaVersion of the report. See the JSON Schema of each kind in docs/schemas
+	SchemaVersion string `json:"schemaVersion"`
+	// Kind of the report (e.g. bundles)
+	Kind string `json:"kind"`
+	// Image is the index image and tag audit
+	Image string `json:"image,omitempty"`
+	// Catalog is the repository of the index image (e.g. quay.io/operatorhubio/catalog)
+	Catalog string `json:"catalog,omitempty"`
+	// Tag is the tag or digest of the index image
+	Tag string `json:"tag,omitempty"`
+	// CatalogDigest is the digest of the index image audit, since the tags are updated
+	CatalogDigest string `json:"catalogDigest,omitempty"`
+	// GeneratedAt is the timestamp in the RFC3339 format or only the date for the legacy reports
+	GeneratedAt string `json:"generatedAt"`
+	// EnabledChecks are the checks done to generate the report (e.g. validators)
+	EnabledChecks []string `json:"enabledChecks,omitempty"`
+	// Tool is the version of the audit-tool which generated the report
+	Tool Build `json:"tool"`
+}
+
+// NewReportMetadata returns the metadata of the report kind generated now for the image informed
+func NewReportMetadata(kind, image string, inspect DockerInspectManifest, checks []string) ReportMetadata {
+	catalog, tag := SplitImageTag(image)
+	return ReportMetadata{
+		SchemaVersion: SchemaVersion,
+		Kind:          kind,
+		Image:         image,
+		Catalog:       catalog,
+		Tag:           tag,
+		CatalogDigest: ImageDigest(catalog, inspect),
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		EnabledChecks: checks,
+		Tool:          BuildInfo,
+	}
+}
+
+// GeneratedDate returns the date when the report was generated, since the legacy reports only have the date
+func (m ReportMetadata) GeneratedDate() string {
+	if t, err := time.Parse(time.RFC3339, m.GeneratedAt); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return m.GeneratedAt
+}
+
+// EnabledChecks returns the checks done in the bundles according to the flags informed
+func EnabledChecks(disableScorecard, disableValidators bool) []string {
+	var checks []string
+	if !disableValidators {
+		checks = append(checks, CheckValidators)
+	}
+	if !disableScorecard {
+		checks = append(checks, CheckScorecard)
+	}
+	return checks
+}
+
+// Migrate checks that the schema version of the metadata is supported and fills the metadata of the
+// legacy reports from the fields which they have
+func (m *ReportMetadata) Migrate(kind, image, generateAt string) error {
+	switch m.SchemaVersion {
+	case SchemaVersion, LegacySchemaVersion:
+		return nil
+	case "":
+		catalog, tag := SplitImageTag(image)
+		*m = ReportMetadata{
+			SchemaVersion: LegacySchemaVersion,
+			Kind:          kind,
+			Image:         image,
+			Catalog:       catalog,
+			Tag:           tag,
+			GeneratedAt:   generateAt,
+		}
+		return nil
+	default:
+		return fmt.Errorf("the schema version %s of the report is not supported by this version of the "+
+			"audit-tool which supports %s. Please, upgrade the audit-tool", m.SchemaVersion, SchemaVersion)
+	}
+}
+
+// ImageDigest returns the digest of the repository informed from the docker inspect
+func ImageDigest(repository string, inspect DockerInspectManifest) string {
+	for _, v := range inspect.RepoDigests {
+		if name, digest := SplitImageTag(v); name == repository && IsDigest(digest) {
+			return digest
+		}
+	}
+	if len(inspect.RepoDigests) > 0 {
+		_, digest := SplitImageTag(inspect.RepoDigests[0])
+		return digest
+	}
+	return ""
+}
+
+// SplitImageTag returns the repository and the tag or digest of the image.
+// (e.g. quay.io/operatorhubio/catalog:latest returns quay.io/operatorhubio/catalog and latest)
+func SplitImageTag(image string) (string, string) {
+	if i := strings.LastIndex(image, "@"); i > 0 {
+		return image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+// IsDigest returns true when the reference of the image (tag or digest) is a digest
+func IsDigest(reference string) bool {
+	return strings.HasPrefix(reference, "sha256:")
+}
+
+// HasRepoDigest returns true when the image inspected was pulled by the digest of the repository informed
+func HasRepoDigest(inspect DockerInspectManifest, repository, digest string) bool {
+	for _, v := range inspect.RepoDigests {
+		if v == fmt.Sprintf("%s@%s", repository, digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageFileName returns the image name formatted to be used in the name of the files
+func ImageFileName(image string) string {
+	name := strings.ReplaceAll(image, "/", "_")
+	name = strings.ReplaceAll(name, ":", "_")
+	return strings.ReplaceAll(name, "-", "_")
+}
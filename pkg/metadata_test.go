@@ -0,0 +1,202 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitImageTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		image          string
+		wantRepository string
+		wantTag        string
+	}{
+		{
+			name:           "should split the tag",
+			image:          "registry.redhat.io/redhat/redhat-operator-index:v4.8",
+			wantRepository: "registry.redhat.io/redhat/redhat-operator-index",
+			wantTag:        "v4.8",
+		},
+		{
+			name:           "should not split the port of the registry",
+			image:          "localhost:5000/catalog",
+			wantRepository: "localhost:5000/catalog",
+		},
+		{
+			name:           "should split the tag when the registry has a port",
+			image:          "localhost:5000/catalog:latest",
+			wantRepository: "localhost:5000/catalog",
+			wantTag:        "latest",
+		},
+		{
+			name:           "should split the digest",
+			image:          "quay.io/operatorhubio/catalog@sha256:9f1c2d",
+			wantRepository: "quay.io/operatorhubio/catalog",
+			wantTag:        "sha256:9f1c2d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, tag := SplitImageTag(tt.image)
+			if repository != tt.wantRepository || tag != tt.wantTag {
+				t.Errorf("SplitImageTag() got = %s, %s, want %s, %s", repository, tag, tt.wantRepository, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestImageDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository string
+		inspect    DockerInspectManifest
+		want       string
+	}{
+		{
+			name:       "should return the digest of the repository",
+			repository: "quay.io/operatorhubio/etcd",
+			inspect:    DockerInspectManifest{RepoDigests: []string{"quay.io/operatorhubio/etcd@sha256:9f1c2d"}},
+			want:       "sha256:9f1c2d",
+		},
+		{
+			name:       "should return the digest of the repository when another repository is listed first",
+			repository: "quay.io/operatorhubio/etcd",
+			inspect: DockerInspectManifest{RepoDigests: []string{
+				"registry.example.com/mirror/etcd@sha256:4a7b3e",
+				"quay.io/operatorhubio/etcd@sha256:9f1c2d",
+			}},
+			want: "sha256:9f1c2d",
+		},
+		{
+			name:       "should return the first digest when the repository is not listed",
+			repository: "quay.io/operatorhubio/etcd",
+			inspect:    DockerInspectManifest{RepoDigests: []string{"registry.example.com/mirror/etcd@sha256:4a7b3e"}},
+			want:       "sha256:4a7b3e",
+		},
+		{
+			name:       "should return empty when the image has no digests",
+			repository: "quay.io/operatorhubio/etcd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ImageDigest(tt.repository, tt.inspect); got != tt.want {
+				t.Errorf("ImageDigest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasRepoDigest(t *testing.T) {
+	inspect := DockerInspectManifest{RepoDigests: []string{
+		"registry.example.com/mirror/etcd@sha256:4a7b3e",
+		"quay.io/operatorhubio/etcd@sha256:9f1c2d",
+	}}
+	tests := []struct {
+		name       string
+		repository string
+		digest     string
+		want       bool
+	}{
+		{
+			name:       "should match the digest of the repository when another repository is listed first",
+			repository: "quay.io/operatorhubio/etcd",
+			digest:     "sha256:9f1c2d",
+			want:       true,
+		},
+		{
+			name:       "should not match the digest of another repository",
+			repository: "quay.io/operatorhubio/etcd",
+			digest:     "sha256:4a7b3e",
+		},
+		{
+			name:       "should not match a digest which is not listed",
+			repository: "quay.io/operatorhubio/etcd",
+			digest:     "sha256:0d5e8f",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasRepoDigest(inspect, tt.repository, tt.digest); got != tt.want {
+				t.Errorf("HasRepoDigest() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDigest(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		want      bool
+	}{
+		{name: "should return true for a digest", reference: "sha256:9f1c2d", want: true},
+		{name: "should return false for a tag", reference: "v0.9.4"},
+		{name: "should return false when the reference is empty", reference: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDigest(tt.reference); got != tt.want {
+				t.Errorf("IsDigest() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportMetadataMigrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata ReportMetadata
+		want     ReportMetadata
+		wantErr  bool
+	}{
+		{
+			name:     "should keep the metadata of the current schema version",
+			metadata: ReportMetadata{SchemaVersion: SchemaVersion, Kind: "bundles", GeneratedAt: "2021-08-16T10:00:00Z"},
+			want:     ReportMetadata{SchemaVersion: SchemaVersion, Kind: "bundles", GeneratedAt: "2021-08-16T10:00:00Z"},
+		},
+		{
+			name: "should fill the metadata of the legacy reports",
+			want: ReportMetadata{
+				SchemaVersion: LegacySchemaVersion,
+				Kind:          "bundles",
+				Image:         "quay.io/operatorhubio/catalog:latest",
+				Catalog:       "quay.io/operatorhubio/catalog",
+				Tag:           "latest",
+				GeneratedAt:   "2021-08-16",
+			},
+		},
+		{
+			name:     "should fail for the schema versions which are not supported",
+			metadata: ReportMetadata{SchemaVersion: "v100"},
+			want:     ReportMetadata{SchemaVersion: "v100"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metadata.Migrate("bundles", "quay.io/operatorhubio/catalog:latest", "2021-08-16")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.metadata, tt.want) {
+				t.Errorf("Migrate() got = %+v, want %+v", tt.metadata, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the Prometheus metrics of the audit results in the text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/reports/custom"
+)
+
+const prefix = "audit_"
+
+// Metric defines a gauge and its samples
+type Metric struct {
+	Name    string
+	Help    string
+	Samples []Sample
+}
+
+// Sample defines a value of the metric with its labels
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Run defines the data of an audit run of a catalog used to generate the metrics
+type Run struct {
+	Catalog   string
+	Bundles   *bundles.Report
+	StartedAt time.Time
+	Duration  time.Duration
+	Errors    int
+}
+
+// registry groups the samples by the metric names
+type registry struct {
+	metrics map[string]*Metric
+}
+
+func (r *registry) add(name, help string, value float64, labels map[string]string) {
+	m, found := r.metrics[name]
+	if !found {
+		m = &Metric{Name: prefix + name, Help: help}
+		r.metrics[name] = m
+	}
+	m.Samples = append(m.Samples, Sample{Labels: labels, Value: value})
+}
+
+// FromRuns returns the metrics per catalog and package for the runs informed
+func FromRuns(runs []Run) []Metric {
+	r := registry{metrics: make(map[string]*Metric)}
+	for _, run := range runs {
+		catalog := map[string]string{"catalog": run.Catalog}
+		r.add("run_duration_seconds", "Duration of the latest audit run of the catalog",
+			run.Duration.Seconds(), catalog)
+		r.add("run_errors", "Number of errors faced in the latest audit run of the catalog",
+			float64(run.Errors), catalog)
+		r.add("run_timestamp_seconds", "Time when the latest audit run of the catalog started",
+			float64(run.StartedAt.Unix()), catalog)
+		if run.Bundles == nil {
+			continue
+		}
+		addPackageMetrics(&r, run.Catalog, run.Bundles)
+		addGradeMetrics(&r, run.Catalog, run.Bundles)
+	}
+
+	var result []Metric
+	for _, m := range r.metrics {
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func addPackageMetrics(r *registry, catalog string, report *bundles.Report) {
+	perPackage := make(map[string][]bundles.Column)
+	for _, v := range report.Columns {
+		perPackage[v.PackageName] = append(perPackage[v.PackageName], v)
+	}
+
+	for name, columns := range perPackage {
+		var validatorErrors, validatorWarnings, scorecardFailures, removedAPIs, auditErrors int
+		for _, c := range columns {
+			validatorErrors += len(c.ValidatorErrors)
+			validatorWarnings += len(c.ValidatorWarnings)
+			scorecardFailures += len(c.ScorecardFailingTests)
+			auditErrors += len(c.AuditErrors)
+			if c.UsesRemovedAPIs() {
+				removedAPIs++
+			}
+		}
+		labels := map[string]string{"catalog": catalog, "package": name}
+		r.add("bundles", "Number of bundles of the package", float64(len(columns)), labels)
+		r.add("validator_errors", "Number of validator errors found in the bundles of the package",
+			float64(validatorErrors), labels)
+		r.add("validator_warnings", "Number of validator warnings found in the bundles of the package",
+			float64(validatorWarnings), labels)
+		r.add("scorecard_failing_tests", "Number of scorecard tests failing for the bundles of the package",
+			float64(scorecardFailures), labels)
+		r.add("bundles_using_removed_apis", "Number of bundles of the package which use APIs removed on 1.22",
+			float64(removedAPIs), labels)
+		r.add("bundle_audit_errors", "Number of errors faced to audit the bundles of the package",
+			float64(auditErrors), labels)
+	}
+}
+
+func addGradeMetrics(r *registry, catalog string, report *bundles.Report) {
+	perGrade := make(map[string]int)
+	for _, v := range custom.NewGradeReport(*report).PackageGrade {
+		perGrade[v.Grade]++
+	}
+	for grade, count := range perGrade {
+		r.add("packages_per_grade", "Number of packages per grade",
+			float64(count), map[string]string{"catalog": catalog, "grade": grade})
+	}
+}
+
+// Write outputs the metrics in the Prometheus text exposition format
+func Write(w io.Writer, metrics []Metric) error {
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", m.Name, m.Help, m.Name); err != nil {
+			return err
+		}
+		lines := make([]string, 0, len(m.Samples))
+		for _, s := range m.Samples {
+			lines = append(lines, fmt.Sprintf("%s%s %s", m.Name, formatLabels(s.Labels),
+				strconv.FormatFloat(s.Value, 'f', -1, 64)))
+		}
+		sort.Strings(lines)
+		if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, k, escaper.Replace(labels[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
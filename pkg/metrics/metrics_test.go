@@ -0,0 +1,61 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+func TestWrite(t *testing.T) {
+	report := &bundles.Report{Columns: []bundles.Column{
+		{PackageName: "etcd", BundleName: "etcd.v0.9.0", ValidatorErrors: []string{"a", "b"},
+			KindsDeprecateAPIs: []string{"CustomResourceDefinition"}},
+		{PackageName: "etcd", BundleName: "etcd.v0.9.2", ValidatorWarnings: []string{"c"},
+			KindsDeprecateAPIs: []string{pkg.Unknown}},
+	}}
+	runs := []Run{{
+		Catalog:   "operatorhub",
+		Bundles:   report,
+		StartedAt: time.Unix(1629158400, 0),
+		Duration:  90 * time.Second,
+		Errors:    1,
+	}}
+
+	var out bytes.Buffer
+	if err := Write(&out, FromRuns(runs)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# TYPE audit_bundles gauge",
+		`audit_bundles{catalog="operatorhub",package="etcd"} 2`,
+		`audit_validator_errors{catalog="operatorhub",package="etcd"} 2`,
+		`audit_validator_warnings{catalog="operatorhub",package="etcd"} 1`,
+		`audit_bundles_using_removed_apis{catalog="operatorhub",package="etcd"} 1`,
+		`audit_run_duration_seconds{catalog="operatorhub"} 90`,
+		`audit_run_errors{catalog="operatorhub"} 1`,
+		`audit_run_timestamp_seconds{catalog="operatorhub"} 1629158400`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Write() output does not contain %s. Output:\n%s", want, out.String())
+		}
+	}
+}
@@ -25,7 +25,6 @@ import (
 // AuditBundle defines the data per bundle which is gathering to generate the reports
 type AuditBundle struct {
 	Bundle                  *apimanifests.Bundle
-	FoundLabel              bool
 	OCPLabel                string
 	BuildAt                 string
 	SkipRangeDB             string
@@ -36,16 +35,37 @@ type AuditBundle struct {
 	ValidatorsResults       []errors.ManifestResult
 	OperatorBundleName      string
 	OperatorBundleImagePath string
+	BundleImageDigest       string
 	CSVFromIndexDB          *v1alpha1.ClusterServiceVersion
 	Channels                []string
 	PackageName             string
 	DefaultChannel          string
 	PropertiesDB            []pkg.PropertiesAnnotation
+	BundleAnnotations       map[string]string
+	BundleImageLabels       map[string]string
+	BundleInconsistencies   []string
+	RelatedImages           []RelatedImage
 	HasCustomScorecardTests bool
 	IsHeadOfChannel         bool
+	HeadOfChannels          []string
 	Errors                  []string
 }
 
+// RelatedImage defines an image referenced by the bundle and where it is referenced
+type RelatedImage struct {
+	Image string `json:"image"`
+	// Sources where the image is referenced (e.g. relatedImages, container:manager, env:RELATED_IMAGE_X)
+	Sources []string `json:"sources"`
+	// Pinned is true when the image is referenced by digest, which is required to mirror it
+	Pinned bool `json:"pinned"`
+	// InRelatedImages is true when the image is in the spec.relatedImages of the CSV
+	InRelatedImages bool `json:"inRelatedImages"`
+	// Architectures provided by the image. It is only set when the images are checked
+	Architectures []string `json:"architectures,omitempty"`
+	// Error is set when the image was checked and it could not be found
+	Error string `json:"error,omitempty"`
+}
+
 func NewAuditBundle(operatorBundleName, operatorBundleImagePath string) *AuditBundle {
 	auditBundle := AuditBundle{}
 	auditBundle.OperatorBundleName = operatorBundleName
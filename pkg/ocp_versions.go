@@ -0,0 +1,164 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// OCPLabel is the label of the bundle images with the OCP versions where they are distributed
+const OCPLabel = "com.redhat.openshift.versions"
+
+// SupportedOCPVersions are the OCP releases currently supported which are used to check the OCP labels
+var SupportedOCPVersions = []string{"4.6", "4.7", "4.8", "4.9"}
+
+// OCPVersionRange defines the OCP versions informed via the OCP label
+type OCPVersionRange struct {
+	// Min is the lower OCP version of the range
+	Min semver.Version
+	// Max is the upper OCP version of the range. It is nil when the range has not an upper version
+	Max *semver.Version
+}
+
+// Includes returns true when the OCP version (e.g. 4.8) is in the range
+func (r OCPVersionRange) Includes(version semver.Version) bool {
+	version = semver.Version{Major: version.Major, Minor: version.Minor}
+	return version.GE(r.Min) && (r.Max == nil || version.LE(*r.Max))
+}
+
+// String returns the range in a readable format, e.g. 4.6-4.8
+func (r OCPVersionRange) String() string {
+	min := fmt.Sprintf("%d.%d", r.Min.Major, r.Min.Minor)
+	switch {
+	case r.Max == nil:
+		return fmt.Sprintf("%s+", min)
+	case r.Max.EQ(r.Min):
+		return min
+	}
+	return fmt.Sprintf("%s-%d.%d", min, r.Max.Major, r.Max.Minor)
+}
+
+// ParseOCPLabel parses the forms of the OCP label: v4.6 (4.6 and upper), v4.6-v4.8 (from 4.6 to 4.8), =v4.7
+// (only 4.7) and the legacy comma-separated lists, such as v4.5,v4.6, which means the lower version and upper
+func ParseOCPLabel(label string) (OCPVersionRange, error) {
+	label = strings.TrimSpace(strings.ReplaceAll(label, "\"", ""))
+	if len(label) == 0 {
+		return OCPVersionRange{}, fmt.Errorf("the %s label is empty", OCPLabel)
+	}
+
+	switch {
+	case strings.HasPrefix(label, "="):
+		version, err := parseOCPVersion(strings.TrimPrefix(label, "="))
+		if err != nil {
+			return OCPVersionRange{}, err
+		}
+		return OCPVersionRange{Min: version, Max: &version}, nil
+	case strings.Contains(label, ","):
+		var r OCPVersionRange
+		for i, v := range strings.Split(label, ",") {
+			version, err := parseOCPVersion(v)
+			if err != nil {
+				return OCPVersionRange{}, err
+			}
+			if i == 0 || version.LT(r.Min) {
+				r.Min = version
+			}
+		}
+		return r, nil
+	case strings.Contains(label, "-"):
+		values := strings.Split(label, "-")
+		if len(values) != 2 {
+			return OCPVersionRange{}, fmt.Errorf("invalid range %s", label)
+		}
+		min, err := parseOCPVersion(values[0])
+		if err != nil {
+			return OCPVersionRange{}, err
+		}
+		max, err := parseOCPVersion(values[1])
+		if err != nil {
+			return OCPVersionRange{}, err
+		}
+		if max.LT(min) {
+			return OCPVersionRange{}, fmt.Errorf("invalid range %s : the upper version is lower than the lower one",
+				label)
+		}
+		return OCPVersionRange{Min: min, Max: &max}, nil
+	}
+
+	version, err := parseOCPVersion(label)
+	if err != nil {
+		return OCPVersionRange{}, err
+	}
+	return OCPVersionRange{Min: version}, nil
+}
+
+// parseOCPVersion parses the versions such as v4.8, where only the major and minor are allowed
+func parseOCPVersion(value string) (semver.Version, error) {
+	value = strings.TrimSpace(value)
+	if strings.Count(value, ".") != 1 {
+		return semver.Version{}, fmt.Errorf("invalid version %s : it should be informed as v<major>.<minor>", value)
+	}
+	version, err := semver.ParseTolerant(value)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid version %s : %s", value, err)
+	}
+	return version, nil
+}
+
+// CheckOCPLabel returns the issues found in the OCP label: malformed values, ranges without any supported OCP
+// version and contradictions with the olm.maxOpenShiftVersion informed
+func CheckOCPLabel(label, maxOCPVersion string, supportedVersions []string) []string {
+	if len(strings.TrimSpace(label)) == 0 {
+		return nil
+	}
+	r, err := ParseOCPLabel(label)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid value %s for the label %s : %s", label, OCPLabel, err)}
+	}
+
+	var issues []string
+	found := false
+	for _, v := range supportedVersions {
+		if version, err := semver.ParseTolerant(v); err == nil && r.Includes(version) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		issues = append(issues, fmt.Sprintf("the range %s of the label %s does not include any supported OCP "+
+			"version (%s)", r, OCPLabel, strings.Join(supportedVersions, ", ")))
+	}
+
+	maxOCPVersion = strings.TrimSpace(strings.ReplaceAll(maxOCPVersion, "\"", ""))
+	if len(maxOCPVersion) == 0 {
+		return issues
+	}
+	max, err := semver.ParseTolerant(maxOCPVersion)
+	if err != nil {
+		return append(issues, fmt.Sprintf("invalid value %s for the olm.maxOpenShiftVersion", maxOCPVersion))
+	}
+	max = semver.Version{Major: max.Major, Minor: max.Minor}
+	if r.Min.GT(max) {
+		issues = append(issues, fmt.Sprintf("the range %s of the label %s starts after the "+
+			"olm.maxOpenShiftVersion %s", r, OCPLabel, maxOCPVersion))
+	} else if r.Max != nil && r.Max.GT(max) {
+		issues = append(issues, fmt.Sprintf("the range %s of the label %s ends after the "+
+			"olm.maxOpenShiftVersion %s", r, OCPLabel, maxOCPVersion))
+	}
+	return issues
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOCPLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		want    string
+		wantErr bool
+	}{
+		{name: "should parse the lower version", label: "v4.6", want: "4.6+"},
+		{name: "should parse the range", label: "v4.6-v4.8", want: "4.6-4.8"},
+		{name: "should parse the exact version", label: "=v4.7", want: "4.7"},
+		{name: "should parse the quoted values", label: "\"=v4.7\"", want: "4.7"},
+		{name: "should parse the comma-separated lists", label: "v4.6,v4.5", want: "4.5+"},
+		{name: "should fail when the range is inverted", label: "v4.8-v4.6", wantErr: true},
+		{name: "should fail when the patch is informed", label: "v4.6.1", wantErr: true},
+		{name: "should fail when the version is invalid", label: "=latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOCPLabel(tt.label)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOCPLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseOCPLabel() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOCPLabel(t *testing.T) {
+	supported := []string{"4.6", "4.7", "4.8"}
+	tests := []struct {
+		name          string
+		label         string
+		maxOCPVersion string
+		want          []string
+	}{
+		{
+			name:          "should not report when the range includes supported versions",
+			label:         "v4.6-v4.8",
+			maxOCPVersion: "\"4.8\"",
+		},
+		{
+			name:  "should report the malformed values",
+			label: "4.6+",
+			want: []string{"invalid value 4.6+ for the label com.redhat.openshift.versions : " +
+				"invalid version 4.6+ : Short version cannot contain PreRelease/Build meta data"},
+		},
+		{
+			name:  "should report the ranges without supported versions",
+			label: "v4.4-v4.5",
+			want: []string{"the range 4.4-4.5 of the label com.redhat.openshift.versions does not include any " +
+				"supported OCP version (4.6, 4.7, 4.8)"},
+		},
+		{
+			name:          "should report the ranges which contradict the max OCP version",
+			label:         "v4.6-v4.8",
+			maxOCPVersion: "4.7",
+			want: []string{"the range 4.6-4.8 of the label com.redhat.openshift.versions ends after the " +
+				"olm.maxOpenShiftVersion 4.7"},
+		},
+		{
+			name:          "should report the ranges which start after the max OCP version",
+			label:         "=v4.8",
+			maxOCPVersion: "4.7",
+			want: []string{"the range 4.8 of the label com.redhat.openshift.versions starts after the " +
+				"olm.maxOpenShiftVersion 4.7"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckOCPLabel(tt.label, tt.maxOCPVersion, supported); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckOCPLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
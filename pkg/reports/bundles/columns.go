@@ -17,6 +17,7 @@ package bundles
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/blang/semver"
@@ -32,8 +33,13 @@ import (
 
 const certifiedAnnotation = "certified"
 const repositoryAnnotation = "repository"
+const capabilitiesAnnotation = "capabilities"
+const categoriesAnnotation = "categories"
 const archLabels = "operatorframework.io/arch."
 const osLabel = "operatorframework.io/os."
+
+// defaultArchitecture is the architecture supported by the bundles without arch labels
+const defaultArchitecture = "amd64"
 const sdkBuilderAnnotation = "operators.operatorframework.io/builder"
 const skipRangeAnnotation = "olm.skipRange"
 const sdkProjectLayoutAnnotation = "operators.operatorframework.io/project_layout"
@@ -42,46 +48,68 @@ const olmproperties = "olm.properties"
 const olmmaxOpenShiftVersion = "olm.maxOpenShiftVersion"
 
 type Column struct {
-	PackageName                 string              `json:"packageName"`
-	BundleName                  string              `json:"bundleName"`
-	BundleVersion               string              `json:"bundleVersion,omitempty"`
-	BundleImagePath             string              `json:"bundleImagePath,omitempty"`
-	BundleImageBuildDate        string              `json:"bundleImageBuildDate,omitempty"`
-	Repository                  string              `json:"repository,omitempty"`
-	DefaultChannel              string              `json:"defaultChannel,omitempty"`
-	Maturity                    string              `json:"maturity,omitempty"`
-	Capabilities                string              `json:"capabilities,omitempty"`
-	Categories                  string              `json:"categories,omitempty"`
-	Builder                     string              `json:"builder,omitempty"`
-	SDKVersion                  string              `json:"sdkVersion,omitempty"`
-	ProjectLayout               string              `json:"projectLayout,omitempty"`
-	InvalidVersioning           string              `json:"invalidVersioning,omitempty"`
-	InvalidSkipRange            string              `json:"invalidSkipRange,omitempty"`
-	SkipRange                   string              `json:"skipRange,omitempty"`
-	Replace                     string              `json:"replace,omitempty"`
-	Infrastructure              string              `json:"infrastructure,omitempty"`
-	OCPLabel                    string              `json:"ocpLabel,omitempty"`
-	MaxOCPVersion               string              `json:"maxOCPVersion,omitempty"`
-	KindsDeprecateAPIs          []string            `json:"kindsDeprecateAPIs,omitempty"`
-	Channels                    []string            `json:"bundleChannel,omitempty"`
-	MultipleArchitectures       []string            `json:"multipleArchitectures,omitempty"`
-	ValidatorErrors             []string            `json:"validatorErrors,omitempty"`
-	ValidatorWarnings           []string            `json:"validatorWarnings,omitempty"`
-	ScorecardErrors             []string            `json:"scorecardErrors,omitempty"`
-	ScorecardSuggestions        []string            `json:"scorecardSuggestions,omitempty"`
-	ScorecardFailingTests       []string            `json:"scorecardFailingTests,omitempty"`
-	AuditErrors                 []string            `json:"errors,omitempty"`
-	Skips                       []string            `json:"skips,omitempty"`
-	DeprecateAPIsManifests      map[string][]string `json:"deprecateAPIsManifests,omitempty"`
-	Certified                   bool                `json:"certified"`
-	HasWebhook                  bool                `json:"hasWebhook"`
-	IsSupportingAllNamespaces   bool                `json:"supportsAllNamespaces"`
-	IsSupportingMultiNamespaces bool                `json:"supportsMultiNamespaces"`
-	IsSupportingSingleNamespace bool                `json:"supportSingleNamespaces"`
-	IsSupportingOwnNamespaces   bool                `json:"supportsOwnNamespaces"`
-	HasPossiblePerformIssues    bool                `json:"hasPossiblePerformIssues"`
-	HasCustomScorecardTests     bool                `json:"hasCustomScorecardTests"`
-	IsHeadOfChannel             bool                `json:"isHeadOfChannel"`
+	PackageName                 string                 `json:"packageName"`
+	BundleName                  string                 `json:"bundleName"`
+	BundleVersion               string                 `json:"bundleVersion,omitempty"`
+	BundleImagePath             string                 `json:"bundleImagePath,omitempty"`
+	BundleImageDigest           string                 `json:"bundleImageDigest,omitempty"`
+	BundleImagePinned           string                 `json:"bundleImagePinned,omitempty"`
+	BundleImageBuildDate        string                 `json:"bundleImageBuildDate,omitempty"`
+	Repository                  string                 `json:"repository,omitempty"`
+	DefaultChannel              string                 `json:"defaultChannel,omitempty"`
+	Maturity                    string                 `json:"maturity,omitempty"`
+	Capabilities                string                 `json:"capabilities,omitempty"`
+	Categories                  string                 `json:"categories,omitempty"`
+	Builder                     string                 `json:"builder,omitempty"`
+	SDKVersion                  string                 `json:"sdkVersion,omitempty"`
+	ProjectLayout               string                 `json:"projectLayout,omitempty"`
+	InvalidVersioning           string                 `json:"invalidVersioning,omitempty"`
+	InvalidSkipRange            string                 `json:"invalidSkipRange,omitempty"`
+	SkipRange                   string                 `json:"skipRange,omitempty"`
+	Replace                     string                 `json:"replace,omitempty"`
+	Infrastructure              string                 `json:"infrastructure,omitempty"`
+	OCPLabel                    string                 `json:"ocpLabel,omitempty"`
+	MaxOCPVersion               string                 `json:"maxOCPVersion,omitempty"`
+	OCPLabelIssues              []string               `json:"ocpLabelIssues,omitempty"`
+	KindsDeprecateAPIs          []string               `json:"kindsDeprecateAPIs,omitempty"`
+	Channels                    []string               `json:"bundleChannel,omitempty"`
+	MultipleArchitectures       []string               `json:"multipleArchitectures,omitempty"`
+	ValidatorErrors             []string               `json:"validatorErrors,omitempty"`
+	ValidatorWarnings           []string               `json:"validatorWarnings,omitempty"`
+	ScorecardErrors             []string               `json:"scorecardErrors,omitempty"`
+	ScorecardSuggestions        []string               `json:"scorecardSuggestions,omitempty"`
+	ScorecardFailingTests       []string               `json:"scorecardFailingTests,omitempty"`
+	AuditErrors                 []string               `json:"errors,omitempty"`
+	RelatedImages               []models.RelatedImage  `json:"relatedImages,omitempty"`
+	ArchitectureMismatches      []string               `json:"architectureMismatches,omitempty"`
+	DisconnectedReasons         []string               `json:"disconnectedReasons,omitempty"`
+	InfrastructureFeatures      InfrastructureFeatures `json:"infrastructureFeatures"`
+	HasFeaturesAnnotations      bool                   `json:"hasFeaturesAnnotations"`
+	InfrastructureIssues        []string               `json:"infrastructureIssues,omitempty"`
+	BundleInconsistencies       []string               `json:"bundleInconsistencies,omitempty"`
+	OwnedAPIs                   []string               `json:"ownedAPIs,omitempty"`
+	RequiredAPIs                []string               `json:"requiredAPIs,omitempty"`
+	RequiredPackages            []string               `json:"requiredPackages,omitempty"`
+	Webhooks                    []Webhook              `json:"webhooks,omitempty"`
+	InstallModeIssues           []string               `json:"installModeIssues,omitempty"`
+	Permissions                 []Permission           `json:"permissions,omitempty"`
+	RBACRisks                   []string               `json:"rbacRisks,omitempty"`
+	RBACRiskScore               int                    `json:"rbacRiskScore"`
+	SecurityIssues              []SecurityIssue        `json:"securityIssues,omitempty"`
+	MetadataIssues              []string               `json:"metadataIssues,omitempty"`
+	MetadataCompleteness        int                    `json:"metadataCompleteness"`
+	Skips                       []string               `json:"skips,omitempty"`
+	DeprecateAPIsManifests      map[string][]string    `json:"deprecateAPIsManifests,omitempty"`
+	Certified                   bool                   `json:"certified"`
+	HasWebhook                  bool                   `json:"hasWebhook"`
+	IsSupportingAllNamespaces   bool                   `json:"supportsAllNamespaces"`
+	IsSupportingMultiNamespaces bool                   `json:"supportsMultiNamespaces"`
+	IsSupportingSingleNamespace bool                   `json:"supportSingleNamespaces"`
+	IsSupportingOwnNamespaces   bool                   `json:"supportsOwnNamespaces"`
+	HasPossiblePerformIssues    bool                   `json:"hasPossiblePerformIssues"`
+	HasCustomScorecardTests     bool                   `json:"hasCustomScorecardTests"`
+	IsHeadOfChannel             bool                   `json:"isHeadOfChannel"`
+	HeadOfChannels              []string               `json:"headOfChannels,omitempty"`
 }
 
 func NewColumn(v models.AuditBundle) *Column {
@@ -90,10 +118,17 @@ func NewColumn(v models.AuditBundle) *Column {
 	col.InvalidVersioning = pkg.Unknown
 	col.PackageName = v.PackageName
 	col.BundleImagePath = v.OperatorBundleImagePath
+	col.BundleImageDigest = v.BundleImageDigest
+	if len(v.OperatorBundleImagePath) > 0 {
+		_, reference := pkg.SplitImageTag(v.OperatorBundleImagePath)
+		col.BundleImagePinned = pkg.GetYesOrNo(pkg.IsDigest(reference))
+	}
 	col.BundleName = v.OperatorBundleName
 	col.DefaultChannel = v.DefaultChannel
 	col.Channels = v.Channels
 	col.AuditErrors = v.Errors
+	col.RelatedImages = v.RelatedImages
+	col.BundleInconsistencies = v.BundleInconsistencies
 	col.SkipRange = v.SkipRangeDB
 	col.Replace = v.ReplacesDB
 	col.BundleVersion = v.VersionDB
@@ -101,6 +136,7 @@ func NewColumn(v models.AuditBundle) *Column {
 	col.BundleImageBuildDate = v.BuildAt
 	col.HasCustomScorecardTests = v.HasCustomScorecardTests
 	col.IsHeadOfChannel = v.IsHeadOfChannel
+	col.HeadOfChannels = v.HeadOfChannels
 
 	var csv *v1alpha1.ClusterServiceVersion
 	if v.Bundle != nil && v.Bundle.CSV != nil {
@@ -110,10 +146,19 @@ func NewColumn(v models.AuditBundle) *Column {
 	}
 
 	col.AddDataFromCSV(csv)
+	col.AddWebhooks(csv)
+	col.CheckInstallModes(csv)
+	col.AddPermissions(csv)
+	col.CheckSecurity(csv)
+	col.CheckMetadata(csv)
+	col.CheckArchitectures(csv, v.RelatedImages)
+	col.CheckDisconnected(csv)
 	col.AddDataFromBundle(v.Bundle)
 	col.AddDataFromScorecard(v.ScorecardResults)
 	col.AddDataFromValidators(v.ValidatorsResults)
 	col.SetMaxOpenshiftVersion(csv, v.PropertiesDB)
+	col.AddDependencies(csv, v.PropertiesDB)
+	col.OCPLabelIssues = pkg.CheckOCPLabel(col.OCPLabel, col.MaxOCPVersion, pkg.SupportedOCPVersions)
 
 	if len(col.BundleVersion) < 1 && len(v.VersionDB) > 0 {
 		col.BundleVersion = v.VersionDB
@@ -184,8 +229,8 @@ func (c *Column) AddDataFromCSV(csv *v1alpha1.ClusterServiceVersion) {
 	}
 	c.HasWebhook = len(csv.Spec.WebhookDefinitions) > 0
 	c.Maturity = csv.Spec.Maturity
-	c.Capabilities = csv.ObjectMeta.Annotations["capabilities"]
-	c.Categories = csv.ObjectMeta.Annotations["categories"]
+	c.Capabilities = csv.ObjectMeta.Annotations[capabilitiesAnnotation]
+	c.Categories = csv.ObjectMeta.Annotations[categoriesAnnotation]
 
 	for k, v := range csv.ObjectMeta.Labels {
 		if strings.Contains(k, archLabels) && v == "supported" {
@@ -206,6 +251,12 @@ func (c *Column) AddDataFromCSV(csv *v1alpha1.ClusterServiceVersion) {
 	}
 
 	c.Infrastructure = csv.ObjectMeta.Annotations[infrastructureAnnotation]
+	features, hasFeaturesAnnotations, errs := ParseInfrastructureFeatures(csv.ObjectMeta.Annotations)
+	c.InfrastructureFeatures = features
+	c.HasFeaturesAnnotations = hasFeaturesAnnotations
+	for _, err := range errs {
+		c.InfrastructureIssues = append(c.InfrastructureIssues, err.Error())
+	}
 
 	if len(c.Infrastructure) > 0 && len(c.MultipleArchitectures) > 0 {
 		c.HasPossiblePerformIssues = true
@@ -240,6 +291,17 @@ func (c *Column) AddDataFromCSV(csv *v1alpha1.ClusterServiceVersion) {
 	}
 }
 
+// UsesRemovedAPIs returns true when the bundle uses APIs removed on 1.22. Note that the bundles which
+// could not be checked have the kind Unknown
+func (c Column) UsesRemovedAPIs() bool {
+	for _, v := range c.KindsDeprecateAPIs {
+		if v != pkg.Unknown {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Column) AddDataFromBundle(bundle *apimanifests.Bundle) {
 	if bundle == nil {
 		c.KindsDeprecateAPIs = []string{pkg.Unknown}
@@ -274,3 +336,88 @@ func (c *Column) AddDataFromValidators(validatorsResults []validationerrors.Mani
 		}
 	}
 }
+
+// countRelatedImages returns the number of related images of the bundle which match the condition
+func (c *Column) countRelatedImages(match func(models.RelatedImage) bool) int {
+	count := 0
+	for _, v := range c.RelatedImages {
+		if match(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckArchitectures compares the architectures declared via the arch labels of the CSV with the ones provided by
+// the images checked. It reports the images which do not provide an architecture declared and the architectures
+// provided by all images which are not declared.
+func (c *Column) CheckArchitectures(csv *v1alpha1.ClusterServiceVersion, images []models.RelatedImage) {
+	if csv == nil {
+		return
+	}
+	declared := declaredArchitectures(csv)
+
+	var provided map[string]bool
+	for _, image := range images {
+		if len(image.Architectures) == 0 {
+			continue
+		}
+		archs := map[string]bool{}
+		for _, arch := range image.Architectures {
+			archs[arch] = true
+		}
+		for _, arch := range declared {
+			if !archs[arch] {
+				c.ArchitectureMismatches = append(c.ArchitectureMismatches,
+					fmt.Sprintf("%s is declared but the image %s does not provide it", arch, image.Image))
+			}
+		}
+		// keeps only the architectures provided by all images
+		if provided == nil {
+			provided = archs
+			continue
+		}
+		for arch := range provided {
+			if !archs[arch] {
+				delete(provided, arch)
+			}
+		}
+	}
+
+	var undeclared []string
+	for arch := range provided {
+		if !contains(declared, arch) {
+			undeclared = append(undeclared, arch)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, arch := range undeclared {
+		c.ArchitectureMismatches = append(c.ArchitectureMismatches,
+			fmt.Sprintf("%s is provided by the images but it is not declared via the label %s%s",
+				arch, archLabels, arch))
+	}
+}
+
+// declaredArchitectures returns the architectures supported according to the arch labels of the CSV
+func declaredArchitectures(csv *v1alpha1.ClusterServiceVersion) []string {
+	var archs []string
+	for k, v := range csv.ObjectMeta.Labels {
+		if strings.HasPrefix(k, archLabels) && v == "supported" {
+			archs = append(archs, strings.TrimPrefix(k, archLabels))
+		}
+	}
+	if len(archs) == 0 {
+		return []string{defaultArchitecture}
+	}
+	sort.Strings(archs)
+	return archs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
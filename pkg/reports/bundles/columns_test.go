@@ -0,0 +1,511 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
+)
+
+func TestCheckArchitectures(t *testing.T) {
+	multiArch := &v1alpha1.ClusterServiceVersion{}
+	multiArch.Labels = map[string]string{
+		"operatorframework.io/arch.amd64": "supported",
+		"operatorframework.io/arch.arm64": "supported",
+		"operatorframework.io/os.linux":   "supported",
+	}
+	tests := []struct {
+		name   string
+		csv    *v1alpha1.ClusterServiceVersion
+		images []models.RelatedImage
+		want   []string
+	}{
+		{
+			name: "should not report mismatches when the images were not checked",
+			csv:  multiArch,
+			images: []models.RelatedImage{
+				{Image: "quay.io/example/operator:v1"},
+			},
+		},
+		{
+			name: "should report the images which do not provide an architecture declared",
+			csv:  multiArch,
+			images: []models.RelatedImage{
+				{Image: "quay.io/example/operator:v1", Architectures: []string{"amd64", "arm64"}},
+				{Image: "quay.io/example/operand:v1", Architectures: []string{"amd64"}},
+			},
+			want: []string{"arm64 is declared but the image quay.io/example/operand:v1 does not provide it"},
+		},
+		{
+			name: "should report the architectures provided by all images which are not declared",
+			csv:  &v1alpha1.ClusterServiceVersion{},
+			images: []models.RelatedImage{
+				{Image: "quay.io/example/operator:v1", Architectures: []string{"amd64", "arm64", "s390x"}},
+				{Image: "quay.io/example/operand:v1", Architectures: []string{"arm64", "amd64"}},
+			},
+			want: []string{"arm64 is provided by the images but it is not declared via the label " +
+				"operatorframework.io/arch.arm64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Column{}
+			c.CheckArchitectures(tt.csv, tt.images)
+			if !reflect.DeepEqual(c.ArchitectureMismatches, tt.want) {
+				t.Errorf("CheckArchitectures() = %v, want %v", c.ArchitectureMismatches, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDisconnected(t *testing.T) {
+	const digest = "quay.io/example/operator@sha256:" +
+		"4b5f3a1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a"
+	csv := &v1alpha1.ClusterServiceVersion{}
+	deployment := v1alpha1.StrategyDeploymentSpec{Name: "operator"}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "manager", Image: digest,
+		Args: []string{"--metrics-addr=http://localhost:8080", "--upstream=https://api.example.com/v1"},
+		Env:  []corev1.EnvVar{{Name: "WEBHOOK_URL", Value: "https://webhook.operators.svc:443/validate"}}}}
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []v1alpha1.StrategyDeploymentSpec{deployment}
+
+	tests := []struct {
+		name   string
+		column Column
+		csv    *v1alpha1.ClusterServiceVersion
+		want   []string
+	}{
+		{
+			name:   "should be ready when declared and all images are pinned and listed",
+			column: Column{InfrastructureFeatures: InfrastructureFeatures{Disconnected: true, ProxyAware: true}},
+			csv:    &v1alpha1.ClusterServiceVersion{},
+		},
+		{
+			name: "should report the images and the external URLs",
+			column: Column{
+				InfrastructureFeatures: InfrastructureFeatures{Disconnected: true},
+				RelatedImages: []models.RelatedImage{
+					{Image: digest, Pinned: true},
+					{Image: "quay.io/example/operand:v1", InRelatedImages: true},
+				},
+			},
+			csv: csv,
+			want: []string{
+				"the image " + digest + " is not in the spec.relatedImages",
+				"the image quay.io/example/operand:v1 is referenced by tag instead of digest",
+				"the external URL https://api.example.com/v1 is hard-coded",
+			},
+		},
+		{
+			name:   "should report when disconnected is not declared",
+			column: Column{InfrastructureFeatures: InfrastructureFeatures{ProxyAware: true}},
+			csv:    &v1alpha1.ClusterServiceVersion{},
+			want: []string{
+				"Disconnected is not declared via the annotation operators.openshift.io/infrastructure-features " +
+					"or features.operators.openshift.io/disconnected: \"true\"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.column
+			c.CheckDisconnected(tt.csv)
+			if !reflect.DeepEqual(c.DisconnectedReasons, tt.want) {
+				t.Errorf("CheckDisconnected() = %v, want %v", c.DisconnectedReasons, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInfrastructureFeatures(t *testing.T) {
+	tests := []struct {
+		name                   string
+		annotations            map[string]string
+		want                   InfrastructureFeatures
+		wantFeaturesAnnotation bool
+		wantErrs               int
+	}{
+		{
+			name:        "should parse the infrastructure-features annotation",
+			annotations: map[string]string{infrastructureAnnotation: `["Disconnected", "Proxy-aware", "fips"]`},
+			want:        InfrastructureFeatures{Disconnected: true, ProxyAware: true, FIPS: true},
+		},
+		{
+			name: "should parse the features annotations",
+			annotations: map[string]string{
+				"features.operators.openshift.io/disconnected":   "true",
+				"features.operators.openshift.io/fips-compliant": "false",
+				"features.operators.openshift.io/token-auth-aws": "true",
+			},
+			want:                   InfrastructureFeatures{Disconnected: true, TokenAuth: true},
+			wantFeaturesAnnotation: true,
+		},
+		{
+			name: "should return the errors of the unknown and invalid values",
+			annotations: map[string]string{
+				infrastructureAnnotation:                      `["Disconnected", "offline"]`,
+				"features.operators.openshift.io/cnf":         "yes",
+				"features.operators.openshift.io/unknown":     "true",
+				"features.operators.openshift.io/proxy-aware": "true",
+			},
+			want:                   InfrastructureFeatures{Disconnected: true, ProxyAware: true},
+			wantFeaturesAnnotation: true,
+			wantErrs:               3,
+		},
+		{
+			name:        "should return an error when the annotation is not a JSON array",
+			annotations: map[string]string{infrastructureAnnotation: "Disconnected"},
+			wantErrs:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotFeaturesAnnotation, errs := ParseInfrastructureFeatures(tt.annotations)
+			if got != tt.want || gotFeaturesAnnotation != tt.wantFeaturesAnnotation || len(errs) != tt.wantErrs {
+				t.Errorf("ParseInfrastructureFeatures() = %+v, %v, %v, want %+v, %v, %d errors",
+					got, gotFeaturesAnnotation, errs, tt.want, tt.wantFeaturesAnnotation, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestCheckInstallModes(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.Spec.InstallModes = []v1alpha1.InstallMode{{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true}}
+
+	tests := []struct {
+		name   string
+		column Column
+		csv    *v1alpha1.ClusterServiceVersion
+		want   []string
+	}{
+		{
+			name:   "should not report issues when the webhooks are defined with AllNamespaces",
+			column: Column{HasWebhook: true, IsSupportingAllNamespaces: true},
+			csv:    csv,
+		},
+		{
+			name:   "should report once the webhooks defined without AllNamespaces",
+			column: Column{HasWebhook: true, IsSupportingOwnNamespaces: true},
+			csv:    csv,
+			want: []string{
+				"webhooks are defined but the AllNamespaces install mode required by OLM is not supported",
+			},
+		},
+		{
+			name:   "should report MultiNamespace without SingleNamespace",
+			column: Column{IsSupportingMultiNamespaces: true},
+			csv:    csv,
+			want: []string{
+				"MultiNamespace is supported but SingleNamespace, which is a subset of it, is not supported",
+			},
+		},
+		{
+			name:   "should report when the install modes are not informed",
+			column: Column{HasWebhook: true},
+			csv:    &v1alpha1.ClusterServiceVersion{},
+			want:   []string{"spec.installModes is not informed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.column
+			c.CheckInstallModes(tt.csv)
+			if !reflect.DeepEqual(c.InstallModeIssues, tt.want) {
+				t.Errorf("CheckInstallModes() = %v, want %v", c.InstallModeIssues, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWebhook(t *testing.T) {
+	none := admissionregistrationv1.SideEffectClassNone
+	some := admissionregistrationv1.SideEffectClassSome
+	deployments := map[string]bool{"operator": true}
+	ownedCRDs := map[string]bool{"memcacheds.cache.example.com": true}
+	tests := []struct {
+		name    string
+		webhook v1alpha1.WebhookDescription
+		want    []string
+	}{
+		{
+			name: "should not report issues for a valid validating webhook",
+			webhook: v1alpha1.WebhookDescription{Type: v1alpha1.ValidatingAdmissionWebhook, DeploymentName: "operator",
+				AdmissionReviewVersions: []string{"v1"}, SideEffects: &none},
+		},
+		{
+			name: "should report the admission webhooks with side effects",
+			webhook: v1alpha1.WebhookDescription{Type: v1alpha1.MutatingAdmissionWebhook, DeploymentName: "operator",
+				SideEffects: &some},
+			want: []string{
+				"admissionReviewVersions is not informed",
+				"sideEffects Some is not allowed by admissionregistration.k8s.io/v1, use None or NoneOnDryRun",
+			},
+		},
+		{
+			name: "should report the conversion webhooks with CRDs not owned and deployments not defined",
+			webhook: v1alpha1.WebhookDescription{Type: v1alpha1.ConversionWebhook, DeploymentName: "other",
+				ConversionCRDs: []string{"memcacheds.cache.example.com", "others.cache.example.com"}},
+			want: []string{
+				"the deployment other is not defined in the install strategy",
+				"the conversion CRD others.cache.example.com is not owned by the CSV",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkWebhook(tt.webhook, deployments, ownedCRDs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPermissions(t *testing.T) {
+	newCSV := func(clusterRules, rules []rbacv1.PolicyRule) *v1alpha1.ClusterServiceVersion {
+		csv := &v1alpha1.ClusterServiceVersion{}
+		csv.Spec.InstallStrategy.StrategySpec.ClusterPermissions = []v1alpha1.StrategyDeploymentPermissions{
+			{ServiceAccountName: "operator", Rules: clusterRules}}
+		csv.Spec.InstallStrategy.StrategySpec.Permissions = []v1alpha1.StrategyDeploymentPermissions{
+			{ServiceAccountName: "operator", Rules: rules}}
+		return csv
+	}
+	tests := []struct {
+		name            string
+		csv             *v1alpha1.ClusterServiceVersion
+		wantPermissions []Permission
+		wantRisks       []string
+		wantScore       int
+	}{
+		{
+			name: "should not report risks for the permissions scoped to the resources required",
+			csv: newCSV([]rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"},
+				Verbs: []string{"list", "get"}}}, nil),
+			wantPermissions: []Permission{
+				{Scope: clusterScope, Resource: "apps/deployments", Verbs: []string{"get", "list"}}},
+		},
+		{
+			name: "should report the cluster-admin equivalent permissions",
+			csv: newCSV([]rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"},
+				Verbs: []string{"*"}}}, nil),
+			wantPermissions: []Permission{{Scope: clusterScope, Resource: "*/*", Verbs: []string{"*"}}},
+			wantRisks: []string{"cluster permissions of the service account operator: all verbs are allowed on all " +
+				"resources, which is equivalent to cluster-admin"},
+			wantScore: 20,
+		},
+		{
+			name: "should report the secrets access and the escalation verbs",
+			csv: newCSV([]rbacv1.PolicyRule{{APIGroups: []string{"rbac.authorization.k8s.io"},
+				Resources: []string{"clusterroles"}, Verbs: []string{"bind"}}},
+				[]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}}),
+			wantPermissions: []Permission{
+				{Scope: clusterScope, Resource: "rbac.authorization.k8s.io/clusterroles", Verbs: []string{"bind"}},
+				{Scope: namespaceScope, Resource: "core/secrets", Verbs: []string{"get"}},
+			},
+			wantRisks: []string{
+				"cluster permissions of the service account operator: the verb bind is allowed on " +
+					"rbac.authorization.k8s.io/clusterroles",
+				"namespace permissions of the service account operator: the secrets can be read",
+			},
+			wantScore: 12,
+		},
+		{
+			name: "should not report the access to the secrets restricted by their names",
+			csv: newCSV(nil, []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"},
+				ResourceNames: []string{"operator-tls"}, Verbs: []string{"get", "watch"}}}),
+			wantPermissions: []Permission{
+				{Scope: namespaceScope, Resource: "core/secrets", Verbs: []string{"get", "watch"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Column{}
+			c.AddPermissions(tt.csv)
+			if !reflect.DeepEqual(c.Permissions, tt.wantPermissions) {
+				t.Errorf("AddPermissions() permissions = %v, want %v", c.Permissions, tt.wantPermissions)
+			}
+			if !reflect.DeepEqual(c.RBACRisks, tt.wantRisks) {
+				t.Errorf("AddPermissions() risks = %v, want %v", c.RBACRisks, tt.wantRisks)
+			}
+			if c.RBACRiskScore != tt.wantScore {
+				t.Errorf("AddPermissions() score = %v, want %v", c.RBACRiskScore, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestCheckContainer(t *testing.T) {
+	yes := true
+	hardened := corev1.Container{Image: "quay.io/example/operator:v1",
+		SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &yes},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		},
+		LivenessProbe:  &corev1.Probe{},
+		ReadinessProbe: &corev1.Probe{},
+	}
+	tests := []struct {
+		name            string
+		container       corev1.Container
+		podRunAsNonRoot bool
+		checkProbes     bool
+		want            []string
+	}{
+		{
+			name:            "should not report issues for a hardened container",
+			container:       hardened,
+			podRunAsNonRoot: true,
+			checkProbes:     true,
+		},
+		{
+			name: "should report all issues of a container without configuration",
+			container: corev1.Container{Image: "quay.io/example/operator",
+				SecurityContext: &corev1.SecurityContext{Privileged: &yes}},
+			checkProbes: true,
+			want: []string{privilegedIssue, runAsNonRootIssue, readOnlyRootFSIssue, resourceRequestsIssue,
+				resourceLimitsIssue, livenessProbeIssue, readinessProbeIssue, latestTagIssue},
+		},
+		{
+			name: "should not check the probes of the init containers",
+			container: corev1.Container{Image: "quay.io/example/operator:latest",
+				SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &yes, ReadOnlyRootFilesystem: &yes},
+				Resources:       hardened.Resources},
+			want: []string{latestTagIssue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkContainer(tt.container, tt.podRunAsNonRoot, tt.checkProbes)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkContainer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckALMExamples(t *testing.T) {
+	newCSV := func(almExamples string) *v1alpha1.ClusterServiceVersion {
+		csv := &v1alpha1.ClusterServiceVersion{}
+		csv.Annotations = map[string]string{almExamplesAnnotation: almExamples}
+		csv.Spec.CustomResourceDefinitions.Owned = []v1alpha1.CRDDescription{
+			{Name: "memcacheds.cache.example.com", Version: "v1alpha1", Kind: "Memcached"},
+		}
+		return csv
+	}
+	tests := []struct {
+		name string
+		csv  *v1alpha1.ClusterServiceVersion
+		want []string
+	}{
+		{
+			name: "should not report issues when all owned CRDs have examples",
+			csv:  newCSV(`[{"apiVersion": "cache.example.com/v1alpha1", "kind": "Memcached"}]`),
+		},
+		{
+			name: "should report the annotation missing",
+			csv:  newCSV(""),
+			want: []string{"the annotation alm-examples is not informed"},
+		},
+		{
+			name: "should report the examples which are not of owned CRDs",
+			csv:  newCSV(`[{"apiVersion": "cache.example.com/v1beta1", "kind": "Memcached"}]`),
+			want: []string{
+				"the example of cache.example.com/v1beta1/Memcached in alm-examples is not of an owned CRD",
+				"the owned CRD cache.example.com/v1alpha1/Memcached has no example in alm-examples",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkALMExamples(tt.csv); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkALMExamples() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMetadata(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.Annotations = map[string]string{
+		capabilitiesAnnotation:   "Basic Install",
+		categoriesAnnotation:     "Database, Unknown",
+		containerImageAnnotation: "quay.io/example/operator:v1",
+	}
+	csv.Spec.Description = "An operator"
+	csv.Spec.Icon = []v1alpha1.Icon{{Data: "iVBORw0KGgo=", MediaType: "image/png"}}
+	csv.Spec.Maintainers = []v1alpha1.Maintainer{{Name: "example", Email: "example@example.com"}}
+	csv.Spec.Links = []v1alpha1.AppLink{{Name: "Docs", URL: "https://example.com"}}
+	csv.Spec.Provider = v1alpha1.AppLink{Name: "Example"}
+
+	c := &Column{}
+	c.CheckMetadata(csv)
+	want := []string{
+		"spec.description has 11 characters, less than the 100 expected",
+		"the category \"Unknown\" is not recognized",
+		"the annotation createdAt is not informed",
+	}
+	if !reflect.DeepEqual(c.MetadataIssues, want) {
+		t.Errorf("CheckMetadata() issues = %v, want %v", c.MetadataIssues, want)
+	}
+	if c.MetadataCompleteness != 70 {
+		t.Errorf("CheckMetadata() completeness = %v, want %v", c.MetadataCompleteness, 70)
+	}
+}
+
+func TestUsesRemovedAPIs(t *testing.T) {
+	tests := []struct {
+		name  string
+		kinds []string
+		want  bool
+	}{
+		{name: "should return true when the bundle uses removed APIs", kinds: []string{"CRD"}, want: true},
+		{name: "should return false when the bundle does not use removed APIs"},
+		{name: "should return false when the bundle could not be checked", kinds: []string{pkg.Unknown}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Column{KindsDeprecateAPIs: tt.kinds}).UsesRemovedAPIs(); got != tt.want {
+				t.Errorf("UsesRemovedAPIs() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddDataFromCSVInfrastructureIssues(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.Annotations = map[string]string{infrastructureAnnotation: `["Disconnected", "Unknown"]`}
+	c := &Column{}
+	c.AddDataFromCSV(csv)
+	want := []string{"unknown infrastructure feature Unknown in the annotation " + infrastructureAnnotation}
+	if !reflect.DeepEqual(c.InfrastructureIssues, want) {
+		t.Errorf("AddDataFromCSV() infrastructure issues = %v, want %v", c.InfrastructureIssues, want)
+	}
+	if len(c.AuditErrors) > 0 {
+		t.Errorf("AddDataFromCSV() audit errors = %v, want none", c.AuditErrors)
+	}
+	if !c.InfrastructureFeatures.Disconnected {
+		t.Errorf("AddDataFromCSV() disconnected = false, want true")
+	}
+}
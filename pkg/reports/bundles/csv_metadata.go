@@ -0,0 +1,243 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// Annotations of the CSV checked for the metadata quality
+const (
+	almExamplesAnnotation    = "alm-examples"
+	containerImageAnnotation = "containerImage"
+	createdAtAnnotation      = "createdAt"
+)
+
+// minDescriptionLength is the minimum length of the spec.description expected to describe the operator
+const minDescriptionLength = 100
+
+// maxIconSize is the maximum size in bytes of the icon expected to be rendered in the catalog UIs
+const maxIconSize = 100 * 1024
+
+// minMetadataCompleteness is the percentage of the metadata checks under which the bundles are highlighted in red
+const minMetadataCompleteness = 50
+
+// capabilityLevels are the values of the capabilities annotation recognized by OperatorHub
+var capabilityLevels = []string{"Basic Install", "Seamless Upgrades", "Full Lifecycle", "Deep Insights", "Auto Pilot"}
+
+// iconMediaTypes are the media types of the icons recognized by OperatorHub
+var iconMediaTypes = []string{"image/gif", "image/jpeg", "image/png", "image/svg+xml"}
+
+// recognizedCategories are the values of the categories annotation recognized by OperatorHub
+var recognizedCategories = []string{"AI/Machine Learning", "Application Runtime", "Big Data", "Cloud Provider",
+	"Developer Tools", "Database", "Drivers and plugins", "Integration & Delivery", "Logging & Tracing",
+	"Modernization & Migration", "Monitoring", "Networking", "OpenShift Optional", "Security", "Storage",
+	"Streaming & Messaging"}
+
+// CheckMetadata sets the issues found with the metadata of the CSV and the percentage of the checks
+// which passed
+func (c *Column) CheckMetadata(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+	checks := [][]string{
+		checkDescription(csv),
+		checkIcon(csv),
+		checkMaintainers(csv),
+		checkLinks(csv),
+		checkProvider(csv),
+		checkCapabilities(csv),
+		checkCategories(csv),
+		checkRequiredAnnotation(csv, containerImageAnnotation),
+		checkRequiredAnnotation(csv, createdAtAnnotation),
+		checkALMExamples(csv),
+	}
+	passed := 0
+	for _, issues := range checks {
+		if len(issues) == 0 {
+			passed++
+		}
+		c.MetadataIssues = append(c.MetadataIssues, issues...)
+	}
+	c.MetadataCompleteness = passed * 100 / len(checks)
+}
+
+func checkDescription(csv *v1alpha1.ClusterServiceVersion) []string {
+	description := strings.TrimSpace(csv.Spec.Description)
+	if len(description) == 0 {
+		return []string{"spec.description is not informed"}
+	}
+	if len(description) < minDescriptionLength {
+		return []string{fmt.Sprintf("spec.description has %d characters, less than the %d expected",
+			len(description), minDescriptionLength)}
+	}
+	return nil
+}
+
+func checkIcon(csv *v1alpha1.ClusterServiceVersion) []string {
+	if len(csv.Spec.Icon) == 0 {
+		return []string{"spec.icon is not informed"}
+	}
+	var issues []string
+	for i, icon := range csv.Spec.Icon {
+		if !contains(iconMediaTypes, icon.MediaType) {
+			issues = append(issues, fmt.Sprintf("spec.icon[%d] has the mediatype %q, which is not one of %s", i,
+				icon.MediaType, strings.Join(iconMediaTypes, ", ")))
+		}
+		data, err := base64.StdEncoding.DecodeString(icon.Data)
+		switch {
+		case err != nil:
+			issues = append(issues, fmt.Sprintf("spec.icon[%d] has an invalid base64data : %s", i, err))
+		case len(data) == 0:
+			issues = append(issues, fmt.Sprintf("spec.icon[%d] has no base64data", i))
+		case len(data) > maxIconSize:
+			issues = append(issues, fmt.Sprintf("spec.icon[%d] has %d bytes, more than the %d expected", i,
+				len(data), maxIconSize))
+		}
+	}
+	return issues
+}
+
+func checkMaintainers(csv *v1alpha1.ClusterServiceVersion) []string {
+	if len(csv.Spec.Maintainers) == 0 {
+		return []string{"spec.maintainers is not informed"}
+	}
+	var issues []string
+	for i, v := range csv.Spec.Maintainers {
+		if len(v.Name) == 0 || len(v.Email) == 0 {
+			issues = append(issues, fmt.Sprintf("spec.maintainers[%d] has no name or email", i))
+		}
+	}
+	return issues
+}
+
+func checkLinks(csv *v1alpha1.ClusterServiceVersion) []string {
+	if len(csv.Spec.Links) == 0 {
+		return []string{"spec.links is not informed"}
+	}
+	var issues []string
+	for i, v := range csv.Spec.Links {
+		if !isValidURL(v.URL) {
+			issues = append(issues, fmt.Sprintf("spec.links[%d] has an invalid url %q", i, v.URL))
+		}
+	}
+	return issues
+}
+
+func checkProvider(csv *v1alpha1.ClusterServiceVersion) []string {
+	if len(csv.Spec.Provider.Name) == 0 {
+		return []string{"spec.provider.name is not informed"}
+	}
+	if len(csv.Spec.Provider.URL) > 0 && !isValidURL(csv.Spec.Provider.URL) {
+		return []string{fmt.Sprintf("spec.provider.url %q is invalid", csv.Spec.Provider.URL)}
+	}
+	return nil
+}
+
+func checkCapabilities(csv *v1alpha1.ClusterServiceVersion) []string {
+	value := csv.ObjectMeta.Annotations[capabilitiesAnnotation]
+	if len(value) == 0 {
+		return []string{fmt.Sprintf("the annotation %s is not informed", capabilitiesAnnotation)}
+	}
+	if !contains(capabilityLevels, value) {
+		return []string{fmt.Sprintf("the annotation %s has the value %q, which is not one of %s",
+			capabilitiesAnnotation, value, strings.Join(capabilityLevels, ", "))}
+	}
+	return nil
+}
+
+func checkCategories(csv *v1alpha1.ClusterServiceVersion) []string {
+	value := csv.ObjectMeta.Annotations[categoriesAnnotation]
+	if len(value) == 0 {
+		return []string{fmt.Sprintf("the annotation %s is not informed", categoriesAnnotation)}
+	}
+	var issues []string
+	for _, v := range strings.Split(value, ",") {
+		if category := strings.TrimSpace(v); !contains(recognizedCategories, category) {
+			issues = append(issues, fmt.Sprintf("the category %q is not recognized", category))
+		}
+	}
+	return issues
+}
+
+func checkRequiredAnnotation(csv *v1alpha1.ClusterServiceVersion, annotation string) []string {
+	if len(strings.TrimSpace(csv.ObjectMeta.Annotations[annotation])) == 0 {
+		return []string{fmt.Sprintf("the annotation %s is not informed", annotation)}
+	}
+	return nil
+}
+
+// checkALMExamples checks that the alm-examples annotation can be parsed, that its examples are of owned CRDs
+// and that each owned CRD has an example
+func checkALMExamples(csv *v1alpha1.ClusterServiceVersion) []string {
+	owned := csv.Spec.CustomResourceDefinitions.Owned
+	value := csv.ObjectMeta.Annotations[almExamplesAnnotation]
+	if len(value) == 0 {
+		if len(owned) == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("the annotation %s is not informed", almExamplesAnnotation)}
+	}
+
+	var examples []struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal([]byte(value), &examples); err != nil {
+		return []string{fmt.Sprintf("unable to parse the annotation %s : %s", almExamplesAnnotation, err)}
+	}
+
+	var issues []string
+	ownedAPIs := make(map[string]bool)
+	for _, crd := range owned {
+		ownedAPIs[APIName(crdGroup(crd.Name), crd.Version, crd.Kind)] = true
+	}
+	examplesAPIs := make(map[string]bool)
+	for _, v := range examples {
+		group, version := splitAPIVersion(v.APIVersion)
+		api := APIName(group, version, v.Kind)
+		examplesAPIs[api] = true
+		if !ownedAPIs[api] {
+			issues = append(issues, fmt.Sprintf("the example of %s in %s is not of an owned CRD", api,
+				almExamplesAnnotation))
+		}
+	}
+	for _, crd := range owned {
+		if api := APIName(crdGroup(crd.Name), crd.Version, crd.Kind); !examplesAPIs[api] {
+			issues = append(issues, fmt.Sprintf("the owned CRD %s has no example in %s", api, almExamplesAnnotation))
+		}
+	}
+	return issues
+}
+
+// splitAPIVersion returns the group and version of the apiVersion, where the group is empty for the core APIs
+func splitAPIVersion(apiVersion string) (string, string) {
+	values := strings.SplitN(apiVersion, "/", 2)
+	if len(values) < 2 {
+		return "", apiVersion
+	}
+	return values[0], values[1]
+}
+
+func isValidURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && len(u.Scheme) > 0 && len(u.Host) > 0
+}
@@ -26,12 +26,15 @@ import (
 	"github.com/operator-framework/audit/pkg"
 
 	"github.com/operator-framework/audit/pkg/models"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 type Data struct {
 	AuditBundle       []models.AuditBundle
 	Flags             BindFlags
 	IndexImageInspect pkg.DockerInspectManifest
+	// PackageNames are the packages resolved from the --package-list and --filter-regex flags
+	PackageNames []string
 }
 
 func (d *Data) PrepareReport() Report {
@@ -40,12 +43,6 @@ func (d *Data) PrepareReport() Report {
 	var allColumns []Column
 	for _, v := range d.AuditBundle {
 		col := NewColumn(v)
-
-		// do not add bundle which has not the label
-		if len(d.Flags.Label) > 0 && !v.FoundLabel {
-			continue
-		}
-
 		allColumns = append(allColumns, *col)
 	}
 
@@ -53,6 +50,13 @@ func (d *Data) PrepareReport() Report {
 		return allColumns[i].PackageName < allColumns[j].PackageName
 	})
 
+	if len(d.Flags.SortBy) > 0 {
+		field, desc := pkg.ParseSortBy(d.Flags.SortBy)
+		if err := pkg.SortByJSONField(allColumns, field, desc); err != nil {
+			log.Errorf("unable to sort the report : %s", err)
+		}
+	}
+
 	finalReport := Report{}
 	finalReport.Flags = d.Flags
 	finalReport.Columns = allColumns
@@ -60,6 +64,15 @@ func (d *Data) PrepareReport() Report {
 
 	dt := time.Now().Format("2006-01-02")
 	finalReport.GenerateAt = dt
+	checks := pkg.EnabledChecks(d.Flags.DisableScorecard, d.Flags.DisableValidators)
+	if d.Flags.CheckRelatedImages {
+		checks = append(checks, pkg.CheckRelatedImages)
+	}
+	image := d.Flags.IndexImage
+	if len(d.Flags.BundleImage) > 0 {
+		image = d.Flags.BundleImage
+	}
+	finalReport.Metadata = pkg.NewReportMetadata(finalReport.Kind(), image, d.IndexImageInspect, checks)
 
 	if len(allColumns) == 0 {
 		log.Fatal("No data was found for the criteria informed. " +
@@ -96,52 +109,72 @@ func (d *Data) OutputReport() error {
 
 	report := d.PrepareReport()
 
-	switch d.Flags.OutputFormat {
-	case pkg.Xls:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-	case pkg.JSON:
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	case pkg.All:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("invalid output format : %s", d.Flags.OutputFormat)
-	}
-	return nil
+	return writers.Output(&report, d.Flags.OutputFormat)
 }
 
-func (d *Data) BuildBundlesQuery() (string, error) {
-	query := sq.Select("o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips").From(
-		"operatorbundle o")
+// BuildBundlesQuery returns the query and its arguments to get the bundles from the index db.
+// The filters informed via the flags are combined, e.g. the --filter, --channel and --head-only can be
+// used together. Note that when --version-range or the label filters are informed the limit is applied by
+// the caller, since the versions and labels can only be checked after the query.
+func (d *Data) BuildBundlesQuery() (string, []interface{}, error) {
+	tables := []string{"operatorbundle o"}
+	var where []sq.Sqlizer
 
+	// channelCol and packageCol are the columns used to filter by the channels and packages
+	var channelCol, packageCol string
 	if d.Flags.HeadOnly {
-		query = sq.Select("o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips").From(
-			"operatorbundle o, channel c")
-		query = query.Where("c.head_operatorbundle_name == o.name")
-	}
-	if d.Flags.Limit > 0 {
-		query = query.Limit(uint64(d.Flags.Limit))
+		tables = append(tables, "channel c")
+		where = append(where, sq.Expr("c.head_operatorbundle_name == o.name"))
+		channelCol, packageCol = "c.name", "c.package_name"
+	} else if len(d.Flags.Filter) > 0 || d.HasPackageNamesFilter() || len(d.Flags.Channel) > 0 ||
+		d.Flags.DefaultChannelOnly {
+		tables = append(tables, "channel_entry e")
+		where = append(where, sq.Expr("e.operatorbundle_name == o.name"))
+		channelCol, packageCol = "e.channel_name", "e.package_name"
 	}
+
 	if len(d.Flags.Filter) > 0 {
-		query = sq.Select("o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips").From(
-			"operatorbundle o, channel_entry c")
-		like := "'%" + d.Flags.Filter + "%'"
-		query = query.Where(fmt.Sprintf("c.operatorbundle_name == o.name AND c.package_name like %s", like))
+		where = append(where, sq.Like{packageCol: "%" + d.Flags.Filter + "%"})
+	}
+	if d.HasPackageNamesFilter() {
+		where = append(where, sq.Eq{packageCol: d.PackageNames})
+	}
+	if len(d.Flags.Channel) > 0 {
+		where = append(where, sq.Eq{channelCol: d.Flags.Channel})
+	}
+	if d.Flags.DefaultChannelOnly {
+		tables = append(tables, "package p")
+		where = append(where, sq.Expr(fmt.Sprintf("p.name == %s AND p.default_channel == %s",
+			packageCol, channelCol)))
+	}
+	for _, property := range d.Flags.Properties {
+		propertyType, value := parseProperty(property)
+		if len(value) == 0 {
+			where = append(where, sq.Expr("EXISTS (SELECT 1 FROM properties pr "+
+				"WHERE pr.operatorbundle_name == o.name AND pr.type == ?)", propertyType))
+			continue
+		}
+		where = append(where, sq.Expr("EXISTS (SELECT 1 FROM properties pr "+
+			"WHERE pr.operatorbundle_name == o.name AND pr.type == ? AND pr.value LIKE ?)",
+			propertyType, "%"+value+"%"))
 	}
 
-	query.OrderBy("o.name")
+	query := sq.Select("o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips").
+		From(strings.Join(tables, ", "))
+	// a bundle is in many channels, so the results need to be distinct when the channel entries are used
+	if len(channelCol) > 0 && !d.Flags.HeadOnly {
+		query = query.Distinct()
+	}
+	for _, w := range where {
+		query = query.Where(w)
+	}
+	if d.Flags.Limit > 0 && !d.HasPostQueryFilters() {
+		query = query.Limit(uint64(d.Flags.Limit))
+	}
 
-	sql, _, err := query.ToSql()
+	sql, args, err := query.ToSql()
 	if err != nil {
-		return "", fmt.Errorf("unable to create sql : %s", err)
+		return "", nil, fmt.Errorf("unable to create sql : %s", err)
 	}
-	return sql, nil
+	return sql, args, nil
 }
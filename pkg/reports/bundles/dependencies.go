@@ -0,0 +1,129 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+// Types of the properties of the index db with the APIs and packages provided and required by the bundles
+const (
+	gvkProperty             = "olm.gvk"
+	gvkRequiredProperty     = "olm.gvk.required"
+	packageRequiredProperty = "olm.package.required"
+)
+
+// gvkValue defines the value of the olm.gvk and olm.gvk.required properties
+type gvkValue struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// packageValue defines the value of the olm.package.required properties
+type packageValue struct {
+	PackageName  string `json:"packageName"`
+	VersionRange string `json:"versionRange"`
+}
+
+// APIName returns the name used in the reports for the API, e.g. etcd.database.coreos.com/v1beta2/EtcdCluster
+func APIName(group, version, kind string) string {
+	return fmt.Sprintf("%s/%s/%s", group, version, kind)
+}
+
+// RequiredPackage returns the name used in the reports for the package required, e.g. etcd (>=0.9.0)
+func RequiredPackage(name, versionRange string) string {
+	if len(versionRange) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, versionRange)
+}
+
+// SplitRequiredPackage returns the name and version range of the package required
+func SplitRequiredPackage(value string) (string, string) {
+	values := strings.SplitN(value, " (", 2)
+	if len(values) == 1 {
+		return value, ""
+	}
+	return values[0], strings.TrimSuffix(values[1], ")")
+}
+
+// AddDependencies sets the APIs owned and the APIs and packages required by the bundle from the CRDs and
+// API services of its CSV and from the properties of the index db
+func (c *Column) AddDependencies(csv *v1alpha1.ClusterServiceVersion, propertiesDB []pkg.PropertiesAnnotation) {
+	if csv != nil {
+		for _, v := range csv.Spec.CustomResourceDefinitions.Owned {
+			c.OwnedAPIs = append(c.OwnedAPIs, APIName(crdGroup(v.Name), v.Version, v.Kind))
+		}
+		for _, v := range csv.Spec.CustomResourceDefinitions.Required {
+			c.RequiredAPIs = append(c.RequiredAPIs, APIName(crdGroup(v.Name), v.Version, v.Kind))
+		}
+		for _, v := range csv.Spec.APIServiceDefinitions.Owned {
+			c.OwnedAPIs = append(c.OwnedAPIs, APIName(v.Group, v.Version, v.Kind))
+		}
+		for _, v := range csv.Spec.APIServiceDefinitions.Required {
+			c.RequiredAPIs = append(c.RequiredAPIs, APIName(v.Group, v.Version, v.Kind))
+		}
+	}
+
+	for _, v := range propertiesDB {
+		switch v.Type {
+		case gvkProperty, gvkRequiredProperty:
+			var gvk gvkValue
+			if err := json.Unmarshal([]byte(v.Value), &gvk); err != nil {
+				c.AuditErrors = append(c.AuditErrors,
+					fmt.Sprintf("unable to parse the property %s with the value %s : %s", v.Type, v.Value, err))
+				continue
+			}
+			if v.Type == gvkProperty {
+				c.OwnedAPIs = append(c.OwnedAPIs, APIName(gvk.Group, gvk.Version, gvk.Kind))
+			} else {
+				c.RequiredAPIs = append(c.RequiredAPIs, APIName(gvk.Group, gvk.Version, gvk.Kind))
+			}
+		case packageRequiredProperty:
+			var required packageValue
+			if err := json.Unmarshal([]byte(v.Value), &required); err != nil {
+				c.AuditErrors = append(c.AuditErrors,
+					fmt.Sprintf("unable to parse the property %s with the value %s : %s", v.Type, v.Value, err))
+				continue
+			}
+			c.RequiredPackages = append(c.RequiredPackages,
+				RequiredPackage(required.PackageName, required.VersionRange))
+		}
+	}
+
+	c.OwnedAPIs = pkg.GetUniqueValues(c.OwnedAPIs)
+	c.RequiredAPIs = pkg.GetUniqueValues(c.RequiredAPIs)
+	c.RequiredPackages = pkg.GetUniqueValues(c.RequiredPackages)
+	sort.Strings(c.OwnedAPIs)
+	sort.Strings(c.RequiredAPIs)
+	sort.Strings(c.RequiredPackages)
+}
+
+// crdGroup returns the group of the CRD from its name, e.g. etcdclusters.etcd.database.coreos.com
+func crdGroup(name string) string {
+	values := strings.SplitN(name, ".", 2)
+	if len(values) < 2 {
+		return ""
+	}
+	return values[1]
+}
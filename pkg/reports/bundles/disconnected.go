@@ -0,0 +1,93 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var urlRegex = regexp.MustCompile(`https?://[^\s"',;]+`)
+
+// CheckDisconnected sets the reasons why the bundle is not ready to be installed in disconnected environments.
+// It should be called after the related images and the infrastructure features were set.
+func (c *Column) CheckDisconnected(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+	if !c.InfrastructureFeatures.Disconnected {
+		c.DisconnectedReasons = append(c.DisconnectedReasons,
+			fmt.Sprintf("Disconnected is not declared via the annotation %s or %s%s: \"true\"",
+				infrastructureAnnotation, featuresAnnotationPrefix, FeatureDisconnected))
+	}
+	for _, v := range c.RelatedImages {
+		if !v.Pinned {
+			c.DisconnectedReasons = append(c.DisconnectedReasons,
+				fmt.Sprintf("the image %s is referenced by tag instead of digest", v.Image))
+		}
+		if !v.InRelatedImages {
+			c.DisconnectedReasons = append(c.DisconnectedReasons,
+				fmt.Sprintf("the image %s is not in the spec.relatedImages", v.Image))
+		}
+	}
+	for _, v := range externalURLs(csv) {
+		c.DisconnectedReasons = append(c.DisconnectedReasons, fmt.Sprintf("the external URL %s is hard-coded", v))
+	}
+}
+
+// externalURLs returns the URLs out of the cluster found in the commands, args and env vars of the containers
+// of the CSV deployments
+func externalURLs(csv *v1alpha1.ClusterServiceVersion) []string {
+	var values []string
+	for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		podSpec := deployment.Spec.Template.Spec
+		containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+		for _, c := range containers {
+			values = append(values, c.Command...)
+			values = append(values, c.Args...)
+			for _, env := range c.Env {
+				values = append(values, env.Value)
+			}
+		}
+	}
+
+	var urls []string
+	for _, v := range values {
+		for _, u := range urlRegex.FindAllString(v, -1) {
+			if isExternalURL(u) && !contains(urls, u) {
+				urls = append(urls, u)
+			}
+		}
+	}
+	return urls
+}
+
+// isExternalURL returns false for the URLs of the cluster services and of the local host
+func isExternalURL(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" || host == "127.0.0.1" || host == "0.0.0.0" || !strings.Contains(host, ".") {
+		return false
+	}
+	return !strings.HasSuffix(host, ".svc") && !strings.HasSuffix(host, ".cluster.local")
+}
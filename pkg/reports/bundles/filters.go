@@ -0,0 +1,139 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// HasPackageNamesFilter returns true when the bundles should be filtered by an exact list of package names
+func (d *Data) HasPackageNamesFilter() bool {
+	return len(d.Flags.PackageList) > 0 || len(d.Flags.FilterRegex) > 0
+}
+
+// HasLabelFilter returns true when the bundles should be filtered by the labels of their images
+func (d *Data) HasLabelFilter() bool {
+	return len(d.Flags.Label) > 0 || len(d.Flags.LabelSelectors) > 0
+}
+
+// HasPostQueryFilters returns true when the bundles are filtered after the query (e.g. by version range)
+func (d *Data) HasPostQueryFilters() bool {
+	return len(d.Flags.VersionRange) > 0 || d.HasLabelFilter()
+}
+
+// ResolvePackageNames sets the names of the packages which should be audit according to the
+// --package-list and --filter-regex flags. The indexPackages are all package names found in the index.
+// Note that when both flags are informed the packages must match both criteria
+func (d *Data) ResolvePackageNames(indexPackages []string) error {
+	if !d.HasPackageNamesFilter() {
+		return nil
+	}
+
+	candidates := indexPackages
+	if len(d.Flags.PackageList) > 0 {
+		list, err := ReadPackageList(d.Flags.PackageList)
+		if err != nil {
+			return err
+		}
+		candidates = intersect(indexPackages, list)
+	}
+
+	d.PackageNames = []string{}
+	if len(d.Flags.FilterRegex) == 0 {
+		d.PackageNames = candidates
+		return nil
+	}
+
+	re, err := regexp.Compile(d.Flags.FilterRegex)
+	if err != nil {
+		return fmt.Errorf("invalid regex %s : %s", d.Flags.FilterRegex, err)
+	}
+	for _, name := range candidates {
+		if re.MatchString(name) {
+			d.PackageNames = append(d.PackageNames, name)
+		}
+	}
+	return nil
+}
+
+// IsInVersionRange returns true when the bundle version matches the range informed via
+// the --version-range flag (e.g. ">=1.0.0 <2.0.0") or when no range was informed
+func (d *Data) IsInVersionRange(version string) bool {
+	if len(d.Flags.VersionRange) == 0 {
+		return true
+	}
+	versionRange, err := semver.ParseRange(d.Flags.VersionRange)
+	if err != nil {
+		return false
+	}
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false
+	}
+	return versionRange(v)
+}
+
+// ReadPackageList returns the package names from a file which has one name per line.
+// Empty lines and lines starting with # are ignored
+func ReadPackageList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the package list %s : %s", path, err)
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read the package list %s : %s", path, err)
+	}
+	return names, nil
+}
+
+// parseProperty returns the type and value from the property filters such as olm.maxOpenShiftVersion=4.8.
+// The value is empty when only the type is informed, which means that the property only needs to be set
+func parseProperty(property string) (string, string) {
+	split := strings.SplitN(property, "=", 2)
+	if len(split) == 1 {
+		return strings.TrimSpace(split[0]), ""
+	}
+	return strings.TrimSpace(split[0]), strings.TrimSpace(split[1])
+}
+
+func intersect(values, allowed []string) []string {
+	result := []string{}
+	for _, v := range values {
+		for _, a := range allowed {
+			if v == a {
+				result = append(result, v)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -16,15 +16,29 @@ package bundles
 
 // BindFlags define the flags used to generate the bundle report
 type BindFlags struct {
-	IndexImage        string `json:"image"`
-	Limit             int32  `json:"limit"`
-	HeadOnly          bool   `json:"headOnly"`
-	DisableScorecard  bool   `json:"disableScorecard"`
-	DisableValidators bool   `json:"disableValidators"`
-	ServerMode        bool   `json:"serverMode"`
-	Label             string `json:"label"`
-	LabelValue        string `json:"labelValue"`
-	Filter            string `json:"filter"`
-	OutputPath        string `json:"outputPath"`
-	OutputFormat      string `json:"outputFormat"`
+	IndexImage         string   `json:"image"`
+	BundleImage        string   `json:"bundleImage,omitempty"`
+	BundleDir          string   `json:"bundleDir,omitempty"`
+	Limit              int32    `json:"limit"`
+	HeadOnly           bool     `json:"headOnly"`
+	DisableScorecard   bool     `json:"disableScorecard"`
+	DisableValidators  bool     `json:"disableValidators"`
+	ServerMode         bool     `json:"serverMode"`
+	Label              string   `json:"label"`
+	LabelValue         string   `json:"labelValue"`
+	LabelSelectors     []string `json:"labelSelectors,omitempty"`
+	Filter             string   `json:"filter"`
+	FilterRegex        string   `json:"filterRegex,omitempty"`
+	PackageList        string   `json:"packageList,omitempty"`
+	Channel            string   `json:"channel,omitempty"`
+	DefaultChannelOnly bool     `json:"defaultChannelOnly,omitempty"`
+	VersionRange       string   `json:"versionRange,omitempty"`
+	Properties         []string `json:"properties,omitempty"`
+	OutputPath         string   `json:"outputPath"`
+	OutputFormat       string   `json:"outputFormat"`
+	Columns            []string `json:"columns,omitempty"`
+	SortBy             string   `json:"sortBy,omitempty"`
+	// CheckRelatedImages inspects the images referenced by the bundles to check that they exist
+	CheckRelatedImages    bool   `json:"checkRelatedImages,omitempty"`
+	RelatedImagesRegistry string `json:"relatedImagesRegistry,omitempty"`
 }
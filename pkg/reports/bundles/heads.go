@@ -0,0 +1,215 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+// ChannelHead defines the head of a channel resolved from the bundles of the report
+type ChannelHead struct {
+	PackageName string
+	Channel     string
+	// Head is the bundle resolved as head of the channel
+	Head string
+	// GraphHeads are the bundles of the channel which are not replaced or skipped by any other bundle of it
+	GraphHeads []string
+	// LatestVersion is the bundle of the channel with the highest semantic version
+	LatestVersion string
+	// Issues are the disagreements found between the index db, the replaces graph and the versions
+	Issues []string
+}
+
+// ParseBundleVersion parses the version of the bundle following semver, where only the prefix v is tolerated
+func ParseBundleVersion(version string) (semver.Version, error) {
+	return semver.Parse(strings.TrimPrefix(strings.TrimSpace(version), "v"))
+}
+
+// CompareBundleVersions compares the versions following semver, where the pre-releases are lower than the
+// releases. The versions which differ only by their build metadata are ordered by it, using the same
+// precedence rules of the pre-release identifiers (e.g. 1.0.0+1 < 1.0.0+2 < 1.0.0+10).
+func CompareBundleVersions(a, b semver.Version) int {
+	if result := a.Compare(b); result != 0 {
+		return result
+	}
+	for i := 0; i < len(a.Build) && i < len(b.Build); i++ {
+		if result := compareIdentifiers(a.Build[i], b.Build[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(a.Build) < len(b.Build):
+		return -1
+	case len(a.Build) > len(b.Build):
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifiers compares numeric identifiers numerically, which are lower than the alphanumeric ones
+func compareIdentifiers(a, b string) int {
+	numA, errA := strconv.ParseUint(a, 10, 64)
+	numB, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		if numA == numB {
+			return 0
+		} else if numA < numB {
+			return -1
+		}
+		return 1
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// ResolveChannelHeads resolves the head of each channel of the bundles as OLM does, from the replaces graph.
+// The head is the bundle which is not replaced or skipped by any other bundle of the channel. When the graph
+// has not a single head, the bundle flagged as head in the index db or the one with the highest version is used.
+// Note that the graph is incomplete when the report has not all bundles of the channel (e.g. --head-only).
+func ResolveChannelHeads(columns []Column) []ChannelHead {
+	type channelKey struct{ pkg, channel string }
+	channels := map[channelKey][]Column{}
+	var keys []channelKey
+	for _, c := range columns {
+		for _, ch := range pkg.GetUniqueValues(c.Channels) {
+			key := channelKey{c.PackageName, ch}
+			if _, found := channels[key]; !found {
+				keys = append(keys, key)
+			}
+			channels[key] = append(channels[key], c)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pkg != keys[j].pkg {
+			return keys[i].pkg < keys[j].pkg
+		}
+		return keys[i].channel < keys[j].channel
+	})
+
+	heads := make([]ChannelHead, 0, len(keys))
+	for _, key := range keys {
+		head := resolveChannelHead(key.channel, channels[key])
+		head.PackageName = key.pkg
+		head.Channel = key.channel
+		heads = append(heads, head)
+	}
+	return heads
+}
+
+func resolveChannelHead(channel string, bundlesOfChannel []Column) ChannelHead {
+	head := ChannelHead{}
+
+	replaced := map[string]bool{}
+	for _, b := range bundlesOfChannel {
+		if len(b.Replace) > 0 {
+			replaced[b.Replace] = true
+		}
+		for _, s := range b.Skips {
+			replaced[s] = true
+		}
+	}
+
+	var indexHead string
+	for _, b := range bundlesOfChannel {
+		if !replaced[b.BundleName] {
+			head.GraphHeads = append(head.GraphHeads, b.BundleName)
+		}
+		if b.isHeadOf(channel) && len(indexHead) == 0 {
+			indexHead = b.BundleName
+		}
+	}
+	sort.Strings(head.GraphHeads)
+	latest, issues := LatestBundleVersion(bundlesOfChannel)
+	if latest >= 0 {
+		head.LatestVersion = bundlesOfChannel[latest].BundleName
+	}
+	head.Issues = append(head.Issues, issues...)
+
+	switch {
+	case len(head.GraphHeads) == 1:
+		head.Head = head.GraphHeads[0]
+	case len(indexHead) > 0:
+		head.Head = indexHead
+	default:
+		head.Head = head.LatestVersion
+	}
+
+	switch {
+	case len(head.GraphHeads) == 0:
+		head.Issues = append(head.Issues, "the replaces graph has no head, since all bundles are replaced or skipped")
+	case len(head.GraphHeads) > 1:
+		head.Issues = append(head.Issues, fmt.Sprintf("the replaces graph has more than one head: %s",
+			strings.Join(head.GraphHeads, ", ")))
+	}
+	if len(head.GraphHeads) == 1 && len(head.LatestVersion) > 0 && head.Head != head.LatestVersion {
+		head.Issues = append(head.Issues, fmt.Sprintf("the head %s resolved from the replaces graph is not the "+
+			"bundle with the highest version %s", head.Head, head.LatestVersion))
+	}
+	if len(head.GraphHeads) == 1 && len(indexHead) > 0 && !isHeadOfChannel(bundlesOfChannel, channel, head.Head) {
+		head.Issues = append(head.Issues, fmt.Sprintf("the head %s resolved from the replaces graph is not a head "+
+			"of channel in the index db", head.Head))
+	}
+	return head
+}
+
+// LatestBundleVersion returns the index of the bundle with the highest version, or -1 when no version is valid,
+// and the issues found with the versions which cannot be parsed, since they are not compared
+func LatestBundleVersion(columns []Column) (int, []string) {
+	latest := -1
+	var latestVersion semver.Version
+	var issues []string
+	for i, b := range columns {
+		version, err := ParseBundleVersion(b.BundleVersion)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("the bundle %s has an invalid version %s : %s",
+				b.BundleName, b.BundleVersion, err))
+			continue
+		}
+		if latest < 0 || CompareBundleVersions(version, latestVersion) > 0 {
+			latest = i
+			latestVersion = version
+		}
+	}
+	return latest, issues
+}
+
+func isHeadOfChannel(bundlesOfChannel []Column, channel, name string) bool {
+	for _, b := range bundlesOfChannel {
+		if b.BundleName == name {
+			return b.isHeadOf(channel)
+		}
+	}
+	return false
+}
+
+// isHeadOf returns true when the bundle is the head of the channel in the index db. The reports generated
+// before the heads were stored per channel only inform if the bundle is the head of any channel.
+func (c Column) isHeadOf(channel string) bool {
+	if len(c.HeadOfChannels) == 0 {
+		return c.IsHeadOfChannel
+	}
+	return contains(c.HeadOfChannels, channel)
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareBundleVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.0", b: "1.0.0-rc.1", want: 1},
+		{a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{a: "1.0.0+2", b: "1.0.0+10", want: -1},
+		{a: "v1.0.0+build", b: "1.0.0", want: 1},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := ParseBundleVersion(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseBundleVersion(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := CompareBundleVersions(a, b); got != tt.want {
+				t.Errorf("CompareBundleVersions() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveChannelHeads(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		want    []ChannelHead
+	}{
+		{
+			name: "should resolve the head from the replaces graph",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9.4", Channels: []string{"alpha"},
+					Replace: "etcd.v0.9.2", IsHeadOfChannel: true},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2", Channels: []string{"alpha"}},
+			},
+			want: []ChannelHead{{PackageName: "etcd", Channel: "alpha", Head: "etcd.v0.9.4",
+				GraphHeads: []string{"etcd.v0.9.4"}, LatestVersion: "etcd.v0.9.4"}},
+		},
+		{
+			name: "should report when the index, the graph and the highest version disagree",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9.4", Channels: []string{"alpha"},
+					IsHeadOfChannel: true},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2", Channels: []string{"alpha"},
+					Skips: []string{"etcd.v0.9.4"}},
+			},
+			want: []ChannelHead{{PackageName: "etcd", Channel: "alpha", Head: "etcd.v0.9.2",
+				GraphHeads: []string{"etcd.v0.9.2"}, LatestVersion: "etcd.v0.9.4",
+				Issues: []string{
+					"the head etcd.v0.9.2 resolved from the replaces graph is not the bundle with the highest " +
+						"version etcd.v0.9.4",
+					"the head etcd.v0.9.2 resolved from the replaces graph is not a head of channel in the index db",
+				}}},
+		},
+		{
+			name: "should report more than one head and the invalid versions",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9", Channels: []string{"alpha"}},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2", Channels: []string{"alpha"}},
+			},
+			want: []ChannelHead{{PackageName: "etcd", Channel: "alpha", Head: "etcd.v0.9.2",
+				GraphHeads: []string{"etcd.v0.9.2", "etcd.v0.9.4"}, LatestVersion: "etcd.v0.9.2",
+				Issues: []string{
+					"the bundle etcd.v0.9.4 has an invalid version 0.9 : No Major.Minor.Patch elements found",
+					"the replaces graph has more than one head: etcd.v0.9.2, etcd.v0.9.4",
+				}}},
+		},
+		{
+			name: "should use the head of each channel in the index db",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9.4",
+					Channels: []string{"alpha", "stable"}, IsHeadOfChannel: true, HeadOfChannels: []string{"alpha"}},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2", Channels: []string{"stable"},
+					IsHeadOfChannel: true, HeadOfChannels: []string{"stable"}},
+			},
+			want: []ChannelHead{
+				{PackageName: "etcd", Channel: "alpha", Head: "etcd.v0.9.4", GraphHeads: []string{"etcd.v0.9.4"},
+					LatestVersion: "etcd.v0.9.4"},
+				{PackageName: "etcd", Channel: "stable", Head: "etcd.v0.9.2",
+					GraphHeads: []string{"etcd.v0.9.2", "etcd.v0.9.4"}, LatestVersion: "etcd.v0.9.4",
+					Issues: []string{"the replaces graph has more than one head: etcd.v0.9.2, etcd.v0.9.4"}},
+			},
+		},
+		{
+			name: "should report when the head of the graph is the head of another channel in the index db",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9.4",
+					Channels: []string{"alpha", "stable"}, Replace: "etcd.v0.9.2", IsHeadOfChannel: true,
+					HeadOfChannels: []string{"alpha"}},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2", Channels: []string{"stable"},
+					IsHeadOfChannel: true, HeadOfChannels: []string{"stable"}},
+			},
+			want: []ChannelHead{
+				{PackageName: "etcd", Channel: "alpha", Head: "etcd.v0.9.4", GraphHeads: []string{"etcd.v0.9.4"},
+					LatestVersion: "etcd.v0.9.4"},
+				{PackageName: "etcd", Channel: "stable", Head: "etcd.v0.9.4", GraphHeads: []string{"etcd.v0.9.4"},
+					LatestVersion: "etcd.v0.9.4",
+					Issues: []string{"the head etcd.v0.9.4 resolved from the replaces graph is not a head of " +
+						"channel in the index db"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveChannelHeads(tt.columns); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveChannelHeads() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestBundleVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		columns    []Column
+		want       int
+		wantIssues []string
+	}{
+		{
+			name: "should return the bundle with the highest version",
+			columns: []Column{
+				{BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2"},
+				{BundleName: "etcd.v0.9.4", BundleVersion: "v0.9.4"},
+			},
+			want: 1,
+		},
+		{
+			name: "should report the versions which cannot be parsed",
+			columns: []Column{
+				{BundleName: "etcd.v1.0", BundleVersion: "1.0"},
+				{BundleName: "etcd.v0.9.2", BundleVersion: "0.9.2"},
+			},
+			want: 1,
+			wantIssues: []string{
+				"the bundle etcd.v1.0 has an invalid version 1.0 : No Major.Minor.Patch elements found",
+			},
+		},
+		{
+			name:    "should return -1 when no version is valid",
+			columns: []Column{{BundleName: "etcd", BundleVersion: ""}},
+			want:    -1,
+			wantIssues: []string{
+				"the bundle etcd has an invalid version  : Version string empty",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issues := LatestBundleVersion(tt.columns)
+			if got != tt.want || !reflect.DeepEqual(issues, tt.wantIssues) {
+				t.Errorf("LatestBundleVersion() = %d, %v, want %d, %v", got, issues, tt.want, tt.wantIssues)
+			}
+		})
+	}
+}
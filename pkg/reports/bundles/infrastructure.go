@@ -0,0 +1,148 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// featuresAnnotationPrefix is the prefix of the annotations which replace the infrastructure-features annotation,
+// e.g. features.operators.openshift.io/disconnected: "true"
+const featuresAnnotationPrefix = "features.operators.openshift.io/"
+
+// Infrastructure features as they are informed via the operators.openshift.io/infrastructure-features annotation
+const (
+	FeatureDisconnected = "disconnected"
+	FeatureProxyAware   = "proxy-aware"
+	FeatureFIPS         = "fips"
+	FeatureCSI          = "csi"
+	FeatureCNF          = "cnf"
+	FeatureCNI          = "cni"
+	FeatureTLSProfiles  = "tls-profiles"
+	FeatureTokenAuth    = "token-auth"
+)
+
+// featuresAnnotations maps the features.operators.openshift.io/* annotations to the features
+var featuresAnnotations = map[string]string{
+	"disconnected":     FeatureDisconnected,
+	"proxy-aware":      FeatureProxyAware,
+	"fips-compliant":   FeatureFIPS,
+	"csi":              FeatureCSI,
+	"cnf":              FeatureCNF,
+	"cni":              FeatureCNI,
+	"tls-profiles":     FeatureTLSProfiles,
+	"token-auth-aws":   FeatureTokenAuth,
+	"token-auth-azure": FeatureTokenAuth,
+	"token-auth-gcp":   FeatureTokenAuth,
+}
+
+// InfrastructureFeatures defines the infrastructure features supported by the bundle
+type InfrastructureFeatures struct {
+	Disconnected bool `json:"disconnected"`
+	ProxyAware   bool `json:"proxyAware"`
+	FIPS         bool `json:"fips"`
+	CSI          bool `json:"csi"`
+	CNF          bool `json:"cnf"`
+	CNI          bool `json:"cni"`
+	TLSProfiles  bool `json:"tlsProfiles"`
+	TokenAuth    bool `json:"tokenAuth"`
+}
+
+// fields returns the fields of the features by their names
+func (f *InfrastructureFeatures) fields() map[string]*bool {
+	return map[string]*bool{
+		FeatureDisconnected: &f.Disconnected,
+		FeatureProxyAware:   &f.ProxyAware,
+		FeatureFIPS:         &f.FIPS,
+		FeatureCSI:          &f.CSI,
+		FeatureCNF:          &f.CNF,
+		FeatureCNI:          &f.CNI,
+		FeatureTLSProfiles:  &f.TLSProfiles,
+		FeatureTokenAuth:    &f.TokenAuth,
+	}
+}
+
+// Names returns the names of the features supported sorted alphabetically
+func (f InfrastructureFeatures) Names() []string {
+	var names []string
+	for k, v := range f.fields() {
+		if *v {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseInfrastructureFeatures returns the features declared via the JSON array of the annotation
+// operators.openshift.io/infrastructure-features (e.g. ["Disconnected", "Proxy-aware"]) and via the
+// features.operators.openshift.io/* annotations. It also returns if the latter are used and the errors found
+// for the unknown or invalid values.
+func ParseInfrastructureFeatures(annotations map[string]string) (InfrastructureFeatures, bool, []error) {
+	features, errs := parseInfrastructureAnnotation(annotations[infrastructureAnnotation])
+	fields := features.fields()
+
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		if strings.HasPrefix(k, featuresAnnotationPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		feature, found := featuresAnnotations[strings.TrimPrefix(k, featuresAnnotationPrefix)]
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown infrastructure feature annotation %s", k))
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(annotations[k])) {
+		case "true":
+			*fields[feature] = true
+		case "false":
+		default:
+			errs = append(errs, fmt.Errorf("invalid value %s for the annotation %s : it should be true or false",
+				annotations[k], k))
+		}
+	}
+	return features, len(keys) > 0, errs
+}
+
+// parseInfrastructureAnnotation returns the features of the JSON array of the infrastructure-features annotation
+func parseInfrastructureAnnotation(value string) (InfrastructureFeatures, []error) {
+	features := InfrastructureFeatures{}
+	if len(strings.TrimSpace(value)) == 0 {
+		return features, nil
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(value), &names); err != nil {
+		return features, []error{fmt.Errorf("invalid value %s for the annotation %s : %s",
+			value, infrastructureAnnotation, err)}
+	}
+	var errs []error
+	fields := features.fields()
+	for _, v := range names {
+		field, found := fields[strings.ToLower(strings.TrimSpace(v))]
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown infrastructure feature %s in the annotation %s",
+				v, infrastructureAnnotation))
+			continue
+		}
+		*field = true
+	}
+	return features, errs
+}
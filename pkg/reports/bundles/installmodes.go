@@ -0,0 +1,126 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"sort"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// InstallModeChange defines a change of the install modes supported between a bundle and the one it replaces.
+// The modes removed break the subscriptions which use them, since OLM is unable to upgrade the operator.
+type InstallModeChange struct {
+	PackageName string
+	Channel     string
+	// From is the bundle replaced
+	From string
+	// To is the bundle which replaces it
+	To      string
+	Added   []string
+	Removed []string
+}
+
+// CheckInstallModes sets the contradictions found with the install modes supported by the CSV.
+// It should be called after the install modes and webhooks were set.
+func (c *Column) CheckInstallModes(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+	if len(csv.Spec.InstallModes) == 0 {
+		c.InstallModeIssues = append(c.InstallModeIssues, "spec.installModes is not informed")
+		return
+	}
+	if len(c.SupportedInstallModes()) == 0 {
+		c.InstallModeIssues = append(c.InstallModeIssues, "no install mode is supported")
+	}
+	if c.IsSupportingMultiNamespaces && !c.IsSupportingSingleNamespace {
+		c.InstallModeIssues = append(c.InstallModeIssues,
+			"MultiNamespace is supported but SingleNamespace, which is a subset of it, is not supported")
+	}
+	if c.HasWebhook && !c.IsSupportingAllNamespaces {
+		c.InstallModeIssues = append(c.InstallModeIssues,
+			"webhooks are defined but the AllNamespaces install mode required by OLM is not supported")
+	}
+}
+
+// SupportedInstallModes returns the install modes supported by the bundle
+func (c *Column) SupportedInstallModes() []string {
+	var modes []string
+	if c.IsSupportingAllNamespaces {
+		modes = append(modes, string(v1alpha1.InstallModeTypeAllNamespaces))
+	}
+	if c.IsSupportingMultiNamespaces {
+		modes = append(modes, string(v1alpha1.InstallModeTypeMultiNamespace))
+	}
+	if c.IsSupportingOwnNamespaces {
+		modes = append(modes, string(v1alpha1.InstallModeTypeOwnNamespace))
+	}
+	if c.IsSupportingSingleNamespace {
+		modes = append(modes, string(v1alpha1.InstallModeTypeSingleNamespace))
+	}
+	return modes
+}
+
+// ResolveInstallModeChanges returns the changes of the install modes supported between the bundles of each
+// channel and the bundles they replace. Note that only the upgrades found in the report are checked.
+func ResolveInstallModeChanges(columns []Column) []InstallModeChange {
+	var changes []InstallModeChange
+	forEachUpgrade(columns, func(channel string, from, to Column) {
+		added := difference(to.SupportedInstallModes(), from.SupportedInstallModes())
+		removed := difference(from.SupportedInstallModes(), to.SupportedInstallModes())
+		if len(added) == 0 && len(removed) == 0 {
+			return
+		}
+		changes = append(changes, InstallModeChange{PackageName: to.PackageName, Channel: channel,
+			From: from.BundleName, To: to.BundleName, Added: added, Removed: removed})
+	})
+	return changes
+}
+
+// forEachUpgrade calls the function for each bundle of each channel which replaces another bundle of the channel
+func forEachUpgrade(columns []Column, upgrade func(channel string, from, to Column)) {
+	for _, head := range ResolveChannelHeads(columns) {
+		bundlesOfChannel := map[string]Column{}
+		var names []string
+		for _, c := range columns {
+			if c.PackageName == head.PackageName && contains(c.Channels, head.Channel) {
+				if _, found := bundlesOfChannel[c.BundleName]; !found {
+					names = append(names, c.BundleName)
+				}
+				bundlesOfChannel[c.BundleName] = c
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			to := bundlesOfChannel[name]
+			if from, found := bundlesOfChannel[to.Replace]; found && len(to.Replace) > 0 {
+				upgrade(head.Channel, from, to)
+			}
+		}
+	}
+}
+
+// difference returns the values which are not in the others
+func difference(values, others []string) []string {
+	var result []string
+	for _, v := range values {
+		if !contains(others, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveInstallModeChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		want    []InstallModeChange
+	}{
+		{
+			name: "should not report the upgrades which keep the install modes",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", Channels: []string{"alpha"}, Replace: "etcd.v0.9.2",
+					IsSupportingAllNamespaces: true},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", Channels: []string{"alpha"},
+					IsSupportingAllNamespaces: true},
+			},
+		},
+		{
+			name: "should report the install modes added and removed by the upgrades",
+			columns: []Column{
+				{PackageName: "etcd", BundleName: "etcd.v0.9.4", Channels: []string{"alpha", "stable"},
+					Replace: "etcd.v0.9.2", IsSupportingAllNamespaces: true},
+				{PackageName: "etcd", BundleName: "etcd.v0.9.2", Channels: []string{"alpha"},
+					IsSupportingOwnNamespaces: true, IsSupportingSingleNamespace: true},
+			},
+			want: []InstallModeChange{{PackageName: "etcd", Channel: "alpha", From: "etcd.v0.9.2", To: "etcd.v0.9.4",
+				Added: []string{"AllNamespaces"}, Removed: []string{"OwnNamespace", "SingleNamespace"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveInstallModeChanges(tt.columns); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveInstallModeChanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
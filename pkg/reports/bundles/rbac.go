@@ -0,0 +1,224 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Scopes of the permissions requested by the CSV
+const (
+	clusterScope   = "cluster"
+	namespaceScope = "namespace"
+)
+
+// Weights of the RBAC risks used to compute the risk score of the bundles. The risks of the cluster
+// permissions weight twice as much as the ones of the namespaced permissions.
+const (
+	clusterAdminRisk = 10
+	escalationRisk   = 5
+	wildcardRisk     = 3
+	secretsRisk      = 2
+)
+
+// highRBACRiskScore is the risk score from which the bundles are highlighted in red, e.g. the ones with
+// cluster-admin equivalent cluster permissions
+const highRBACRiskScore = 2 * clusterAdminRisk
+
+// escalationVerbs are the verbs which allow to gain permissions not granted
+var escalationVerbs = []string{"escalate", "bind", "impersonate"}
+
+// Permission defines the verbs allowed on a resource by the permissions requested by the CSV
+type Permission struct {
+	// Scope is cluster or namespace
+	Scope string `json:"scope"`
+	// Resource is the group/resource, where the core group is informed as core, or the non-resource URL
+	Resource string   `json:"resource"`
+	Verbs    []string `json:"verbs"`
+}
+
+// String returns the permission formatted for the output, e.g. cluster apps/deployments: get, list
+func (p Permission) String() string {
+	return fmt.Sprintf("%s %s: %s", p.Scope, p.Resource, strings.Join(p.Verbs, ", "))
+}
+
+// PermissionChange defines the permissions added by a bundle in relation to the bundle it replaces
+type PermissionChange struct {
+	PackageName string
+	Channel     string
+	// From is the bundle replaced
+	From string
+	// To is the bundle which replaces it
+	To string
+	// Added are the verbs allowed on each resource which were not allowed by the bundle replaced
+	Added []Permission
+}
+
+// AddPermissions sets the permissions requested by the CSV, the RBAC risks found with them and the risk score
+func (c *Column) AddPermissions(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+	strategy := csv.Spec.InstallStrategy.StrategySpec
+	for _, p := range strategy.ClusterPermissions {
+		for _, rule := range p.Rules {
+			c.checkRule(clusterScope, p.ServiceAccountName, rule)
+			c.addPermissions(clusterScope, rule)
+		}
+	}
+	for _, p := range strategy.Permissions {
+		for _, rule := range p.Rules {
+			c.checkRule(namespaceScope, p.ServiceAccountName, rule)
+			c.addPermissions(namespaceScope, rule)
+		}
+	}
+
+	sort.Slice(c.Permissions, func(i, j int) bool {
+		if c.Permissions[i].Scope != c.Permissions[j].Scope {
+			return c.Permissions[i].Scope < c.Permissions[j].Scope
+		}
+		return c.Permissions[i].Resource < c.Permissions[j].Resource
+	})
+}
+
+// checkRule adds the RBAC risks found with the rule and their weight to the risk score
+func (c *Column) checkRule(scope, serviceAccount string, rule rbacv1.PolicyRule) {
+	multiplier := 1
+	if scope == clusterScope {
+		multiplier = 2
+	}
+	add := func(weight int, format string, args ...interface{}) {
+		risk := fmt.Sprintf("%s permissions of the service account %s: %s", scope, serviceAccount,
+			fmt.Sprintf(format, args...))
+		if contains(c.RBACRisks, risk) {
+			return
+		}
+		c.RBACRisks = append(c.RBACRisks, risk)
+		c.RBACRiskScore += weight * multiplier
+	}
+
+	resources := ruleResources(rule)
+	allVerbs := contains(rule.Verbs, rbacv1.VerbAll)
+	if allVerbs && contains(rule.APIGroups, rbacv1.APIGroupAll) && contains(rule.Resources, rbacv1.ResourceAll) {
+		add(clusterAdminRisk, "all verbs are allowed on all resources, which is equivalent to cluster-admin")
+		return
+	}
+	if allVerbs {
+		add(wildcardRisk, "all verbs (*) are allowed on %s", strings.Join(resources, ", "))
+	}
+	if contains(rule.APIGroups, rbacv1.APIGroupAll) || contains(rule.Resources, rbacv1.ResourceAll) {
+		add(wildcardRisk, "the verbs %s are allowed on all resources (%s)", strings.Join(rule.Verbs, ", "),
+			strings.Join(resources, ", "))
+	}
+	for _, verb := range escalationVerbs {
+		if contains(rule.Verbs, verb) {
+			add(escalationRisk, "the verb %s is allowed on %s", verb, strings.Join(resources, ", "))
+		}
+	}
+	if allowsSecretsAccess(rule) {
+		add(secretsRisk, "the secrets can be read")
+	}
+}
+
+// allowsSecretsAccess returns true when the rule allows to read the secrets. The rules restricted to
+// some secrets via the resourceNames are not reported.
+func allowsSecretsAccess(rule rbacv1.PolicyRule) bool {
+	if len(rule.ResourceNames) > 0 {
+		return false
+	}
+	if !contains(rule.APIGroups, "") && !contains(rule.APIGroups, rbacv1.APIGroupAll) {
+		return false
+	}
+	if !contains(rule.Resources, "secrets") && !contains(rule.Resources, rbacv1.ResourceAll) {
+		return false
+	}
+	for _, verb := range []string{"get", "list", "watch", rbacv1.VerbAll} {
+		if contains(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleResources returns the resources of the rule in the format group/resource, where the core group is
+// informed as core
+func ruleResources(rule rbacv1.PolicyRule) []string {
+	var resources []string
+	for _, group := range rule.APIGroups {
+		if len(group) == 0 {
+			group = "core"
+		}
+		for _, resource := range rule.Resources {
+			resources = append(resources, fmt.Sprintf("%s/%s", group, resource))
+		}
+	}
+	return append(resources, rule.NonResourceURLs...)
+}
+
+// addPermissions adds the verbs allowed by the rule to the permissions of each resource of the scope
+func (c *Column) addPermissions(scope string, rule rbacv1.PolicyRule) {
+	for _, resource := range ruleResources(rule) {
+		i := findPermission(c.Permissions, scope, resource)
+		if i < 0 {
+			c.Permissions = append(c.Permissions, Permission{Scope: scope, Resource: resource})
+			i = len(c.Permissions) - 1
+		}
+		for _, verb := range rule.Verbs {
+			if !contains(c.Permissions[i].Verbs, verb) {
+				c.Permissions[i].Verbs = append(c.Permissions[i].Verbs, verb)
+			}
+		}
+		sort.Strings(c.Permissions[i].Verbs)
+	}
+}
+
+// findPermission returns the index of the permission of the resource in the scope or -1 when it is not found
+func findPermission(permissions []Permission, scope, resource string) int {
+	for i, p := range permissions {
+		if p.Scope == scope && p.Resource == resource {
+			return i
+		}
+	}
+	return -1
+}
+
+// ResolvePermissionChanges returns the permissions added by the bundles of each channel in relation to the
+// bundles they replace. Note that only the upgrades found in the report are checked.
+func ResolvePermissionChanges(columns []Column) []PermissionChange {
+	var changes []PermissionChange
+	forEachUpgrade(columns, func(channel string, from, to Column) {
+		var added []Permission
+		for _, p := range to.Permissions {
+			var allowed []string
+			if i := findPermission(from.Permissions, p.Scope, p.Resource); i >= 0 {
+				allowed = from.Permissions[i].Verbs
+			}
+			if verbs := difference(p.Verbs, allowed); len(verbs) > 0 {
+				added = append(added, Permission{Scope: p.Scope, Resource: p.Resource, Verbs: verbs})
+			}
+		}
+		if len(added) == 0 {
+			return
+		}
+		changes = append(changes, PermissionChange{PackageName: to.PackageName, Channel: channel,
+			From: from.BundleName, To: to.BundleName, Added: added})
+	})
+	return changes
+}
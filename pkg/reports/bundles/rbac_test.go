@@ -0,0 +1,42 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolvePermissionChanges(t *testing.T) {
+	columns := []Column{
+		{PackageName: "etcd", BundleName: "etcd.v0.9.4", Channels: []string{"alpha"}, Replace: "etcd.v0.9.2",
+			Permissions: []Permission{
+				{Scope: clusterScope, Resource: "apps/deployments", Verbs: []string{"get", "list", "watch"}},
+				{Scope: namespaceScope, Resource: "core/secrets", Verbs: []string{"get"}},
+			}},
+		{PackageName: "etcd", BundleName: "etcd.v0.9.2", Channels: []string{"alpha"},
+			Permissions: []Permission{
+				{Scope: clusterScope, Resource: "apps/deployments", Verbs: []string{"get", "list"}},
+			}},
+	}
+	want := []PermissionChange{{PackageName: "etcd", Channel: "alpha", From: "etcd.v0.9.2", To: "etcd.v0.9.4",
+		Added: []Permission{
+			{Scope: clusterScope, Resource: "apps/deployments", Verbs: []string{"watch"}},
+			{Scope: namespaceScope, Resource: "core/secrets", Verbs: []string{"get"}},
+		}}}
+	if got := ResolvePermissionChanges(columns); !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolvePermissionChanges() = %+v, want %+v", got, want)
+	}
+}
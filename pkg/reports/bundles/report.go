@@ -18,12 +18,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
-	"time"
+	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/360EntSecGroup-Skylar/excelize/v2"
 	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/models"
 )
 
 type Report struct {
@@ -31,352 +32,762 @@ type Report struct {
 	Flags             BindFlags
 	IndexImageInspect pkg.DockerInspectManifest
 	GenerateAt        string
+	Metadata          pkg.ReportMetadata `json:"metadata"`
 }
 
-//todo: fix the complexity
-//nolint:gocyclo
-func (r *Report) writeXls() error {
-	const sheetName = "Sheet1"
-	f := excelize.NewFile()
+// MarshalJSON outputs only the fields of the columns selected via the --columns flag
+func (r Report) MarshalJSON() ([]byte, error) {
+	// report is used to avoid calling MarshalJSON recursively
+	type report Report
+	if len(r.Flags.Columns) == 0 {
+		return json.Marshal(report(r))
+	}
 
-	styleOrange, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#ec8f1c",
-		},
-	})
+	columns := make([]json.RawMessage, 0, len(r.Columns))
+	for _, c := range r.Columns {
+		data, err := pkg.SelectJSONFields(c, r.Flags.Columns)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, data)
+	}
 
-	styleRed, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#EC1C1C",
-		},
+	return json.Marshal(struct {
+		report
+		Columns []json.RawMessage
+	}{report: report(r), Columns: columns})
+}
+
+const validatorFindingsSheet = "Validator Findings"
+const scorecardTestsSheet = "Scorecard Tests"
+const deprecatedAPIsSheet = "Deprecated API Manifests"
+const auditErrorsSheet = "Audit Errors"
+const relatedImagesSheet = "Related Images"
+const architecturesSheet = "Architecture Mismatches"
+const disconnectedSheet = "Disconnected Readiness"
+const inconsistenciesSheet = "Bundle Inconsistencies"
+const channelHeadsSheet = "Channel Heads"
+const webhooksSheet = "Webhooks"
+const installModeChangesSheet = "Install Mode Changes"
+const rbacRisksSheet = "RBAC Risks"
+const permissionChangesSheet = "Permission Growth"
+const securitySheet = "Deployment Security"
+const metadataSheet = "Metadata Issues"
+
+// Migrate checks that the schema version of the report is supported and fills the metadata of the
+// reports generated before it was added
+func (r *Report) Migrate() error {
+	if err := r.Metadata.Migrate(r.Kind(), r.Flags.IndexImage, r.GenerateAt); err != nil {
+		return err
+	}
+	if r.Metadata.SchemaVersion == pkg.LegacySchemaVersion {
+		// the legacy reports have only the raw value of the infrastructure-features annotation
+		for i := range r.Columns {
+			features, errs := parseInfrastructureAnnotation(r.Columns[i].Infrastructure)
+			r.Columns[i].InfrastructureFeatures = features
+			for _, err := range errs {
+				r.Columns[i].InfrastructureIssues = append(r.Columns[i].InfrastructureIssues, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// Schema returns the JSON Schema of the report
+func Schema() *pkg.JSONSchema {
+	s := pkg.NewJSONSchema("Audit Bundles Report", Report{})
+	s.Properties["metadata"].Properties["schemaVersion"].Enum = []string{pkg.SchemaVersion}
+	// the columns can be selected via the --columns flag
+	s.Properties["Columns"].Items.Required = nil
+	return s
+}
+
+// ValidateColumns returns an error when a name informed is not a column of the report
+func ValidateColumns(names []string) error {
+	_, err := pkg.SelectColumns((&Report{}).workbookColumns(), names)
+	return err
+}
+
+// Workbook returns the tabular representation of the report used by the writers
+func (r *Report) Workbook() *pkg.Workbook {
+	dt := r.Metadata.GeneratedDate()
+	if len(dt) == 0 {
+		dt = r.GenerateAt
+	}
+	columns, err := pkg.SelectColumns(r.workbookColumns(), r.Flags.Columns)
+	if err != nil {
+		log.Errorf("unable to select the columns, all columns will be output : %s", err)
+		columns = r.workbookColumns()
+	}
+	return &pkg.Workbook{
+		Title:     fmt.Sprintf("Audit Bundle Report (Generated at %s)", dt),
+		Metadata:  r.workbookMetadata(),
+		Counts:    r.workbookCounts(),
+		Charts:    r.workbookCharts(),
+		TableName: "Bundles",
+		Columns:   columns,
+		Rows:      len(r.Columns),
+		Details:   r.workbookDetails(),
+	}
+}
+
+func (r *Report) workbookMetadata() []pkg.SummaryField {
+	if len(r.Flags.BundleDir) > 0 {
+		return []pkg.SummaryField{
+			{Name: "Bundle directory used", Value: r.Flags.BundleDir},
+			{Name: "Generated at", Value: r.GenerateAt},
+			{Name: "Scorecard enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableScorecard)},
+			{Name: "Validators enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableValidators)},
+		}
+	}
+	if len(r.Flags.BundleImage) > 0 {
+		var digest string
+		if len(r.Columns) > 0 {
+			digest = r.Columns[0].BundleImageDigest
+		}
+		return []pkg.SummaryField{
+			{Name: "Bundle image used", Value: r.Flags.BundleImage},
+			{Name: "Bundle Image Digest", Value: digest},
+			{Name: "Generated at", Value: r.Metadata.GeneratedAt},
+			{Name: "Audit Tool Version", Value: r.Metadata.Tool.Version},
+			{Name: "Scorecard enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableScorecard)},
+			{Name: "Validators enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableValidators)},
+		}
+	}
+	return []pkg.SummaryField{
+		{Name: "Image used", Value: r.Flags.IndexImage},
+		{Name: "Image Index Create Date", Value: r.IndexImageInspect.Created},
+		{Name: "Image Index ID", Value: r.IndexImageInspect.ID},
+		{Name: "Image Index Digest", Value: r.Metadata.CatalogDigest},
+		{Name: "Generated at", Value: r.Metadata.GeneratedAt},
+		{Name: "Audit Tool Version", Value: r.Metadata.Tool.Version},
+		{Name: "Head only", Value: pkg.GetYesOrNo(r.Flags.HeadOnly)},
+		{Name: "Filter", Value: r.Flags.Filter},
+		{Name: "Scorecard enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableScorecard)},
+		{Name: "Validators enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableValidators)},
+	}
+}
+
+func (r *Report) workbookCounts() []pkg.SummaryField {
+	rows := len(r.Columns)
+	var packages []string
+	for _, v := range r.Columns {
+		packages = append(packages, v.PackageName)
+	}
+	return []pkg.SummaryField{
+		{Name: "Packages", Value: len(pkg.GetUniqueValues(packages))},
+		{Name: "Bundles", Value: rows},
+		{Name: "Head of channels", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].IsHeadOfChannel
+		})},
+		{Name: "Bundles with validator errors", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].ValidatorErrors) > 0
+		})},
+		{Name: "Bundles with validator warnings", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].ValidatorWarnings) > 0
+		})},
+		{Name: "Bundles with scorecard failing tests", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].ScorecardFailingTests) > 0
+		})},
+		{Name: "Bundles using removed API(s) on 1.22", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].UsesRemovedAPIs()
+		})},
+		{Name: "Bundles with audit errors", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].AuditErrors) > 0
+		})},
+		{Name: "Bundles referenced by tag", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].BundleImagePinned == pkg.No
+		})},
+		{Name: "Channels with head disagreements", Value: countChannelHeadsWithIssues(r.Columns)},
+		{Name: "Bundles with OCP label issues", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].OCPLabelIssues) > 0
+		})},
+		{Name: "Bundles with labels inconsistent with annotations.yaml", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].BundleInconsistencies) > 0
+		})},
+		{Name: "Bundles with related images referenced by tag", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].countRelatedImages(func(v models.RelatedImage) bool { return !v.Pinned }) > 0
+		})},
+		{Name: "Bundles with architecture mismatches", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].ArchitectureMismatches) > 0
+		})},
+		{Name: "Bundles ready for disconnected environments", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].DisconnectedReasons) == 0
+		})},
+		{Name: "Bundles with images missing in relatedImages", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].countRelatedImages(func(v models.RelatedImage) bool { return !v.InRelatedImages }) > 0
+		})},
+		{Name: "Bundles with install mode issues", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].InstallModeIssues) > 0
+		})},
+		{Name: "Upgrades removing install modes", Value: countInstallModesRemoved(r.Columns)},
+		{Name: "Bundles with RBAC risks", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].RBACRisks) > 0
+		})},
+		{Name: "Bundles with high RBAC risk score", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].RBACRiskScore >= highRBACRiskScore
+		})},
+		{Name: "Upgrades adding permissions", Value: len(ResolvePermissionChanges(r.Columns))},
+		{Name: "Bundles with complete metadata", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].MetadataCompleteness == 100
+		})},
+		{Name: "Bundles with deployment security issues", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return len(r.Columns[i].SecurityIssues) > 0
+		})},
+		{Name: "Bundles with privileged containers", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].hasSecurityIssue(privilegedIssue)
+		})},
+		{Name: "Bundles with webhook issues", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].countWebhookIssues() > 0
+		})},
+		{Name: "Bundles with related images unavailable", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].countRelatedImages(func(v models.RelatedImage) bool { return len(v.Error) > 0 }) > 0
+		})},
+	}
+}
+
+func (r *Report) workbookCharts() []pkg.SummaryChart {
+	rows := len(r.Columns)
+	withErrors := pkg.CountRowsWith(rows, func(i int) bool {
+		return len(r.Columns[i].ValidatorErrors) > 0
+	})
+	onlyWarnings := pkg.CountRowsWith(rows, func(i int) bool {
+		return len(r.Columns[i].ValidatorErrors) == 0 && len(r.Columns[i].ValidatorWarnings) > 0
+	})
+	usingRemovedAPIs := pkg.CountRowsWith(rows, func(i int) bool {
+		return r.Columns[i].UsesRemovedAPIs()
 	})
 
-	styleGreen, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#3FA91E",
+	var charts []pkg.SummaryChart
+	if !r.Flags.DisableValidators {
+		charts = append(charts, pkg.SummaryChart{
+			Title: "Validators",
+			Type:  "pie",
+			Series: []pkg.SummaryField{
+				{Name: "Errors", Value: withErrors},
+				{Name: "Only warnings", Value: onlyWarnings},
+				{Name: "Pass", Value: rows - withErrors - onlyWarnings},
+			},
+		})
+	}
+	charts = append(charts, pkg.SummaryChart{
+		Title: "Removed API(s) on 1.22",
+		Type:  "pie",
+		Series: []pkg.SummaryField{
+			{Name: "Using", Value: usingRemovedAPIs},
+			{Name: "Not using", Value: rows - usingRemovedAPIs},
 		},
 	})
-
-	styleWrapText, _ := f.NewStyle(&excelize.Style{
-		Alignment: &excelize.Alignment{
-			WrapText: true,
+	charts = append(charts, pkg.SummaryChart{
+		Title: "Install modes supported",
+		Type:  "col",
+		Series: []pkg.SummaryField{
+			{Name: "AllNamespaces", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return r.Columns[i].IsSupportingAllNamespaces
+			})},
+			{Name: "SingleNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return r.Columns[i].IsSupportingSingleNamespace
+			})},
+			{Name: "OwnNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return r.Columns[i].IsSupportingOwnNamespaces
+			})},
+			{Name: "MultiNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return r.Columns[i].IsSupportingMultiNamespaces
+			})},
 		},
 	})
+	return charts
+}
 
-	columns := map[string]string{
-		"A":  "Package Name",
-		"B":  "Repository",
-		"C":  "OCP Labels Version",
-		"D":  "Maturity",
-		"E":  "Capabilities",
-		"F":  "Categories",
-		"G":  "Multiple Architectures",
-		"H":  "Certified",
-		"I":  "Kinds (Deprecated APIs on 1.22)",
-		"J":  "Operator Bundle Name",
-		"K":  "Operator Bundle Version",
-		"L":  "Default Channel",
-		"M":  "Bundle Channel",
-		"N":  "Build Date (from index image)",
-		"O":  "Bundle Path",
-		"P":  "Has webhooks",
-		"Q":  "Builder",
-		"R":  "SDK Version",
-		"S":  "Project Layout",
-		"T":  "Scorecard Failing Tests",
-		"U":  "Scorecard Suggestions",
-		"V":  "Scorecard Errors",
-		"W":  "Validator Errors",
-		"X":  "Validator Warnings",
-		"Y":  "Invalid Versioning",
-		"Z":  "Invalid SkipRange",
-		"AA": "Is head of channel",
-		"AB": "Skip Range",
-		"AC": "Skips",
-		"AD": "Replace",
-		"AE": "Supports All Namespaces",
-		"AF": "Supports Single Namespaces",
-		"AG": "Supports Own Namespaces",
-		"AH": "Supports Multi Namespaces",
-		"AI": "Infrastructure Annotations",
-		"AJ": "Has possible performance issues",
-		"AK": "Suggestion API(s) manifests",
-		"AL": "Max OCP Version",
-		"AM": "Has custom Scorecards",
-		"AN": "Issues (To process this report)",
+func (r *Report) workbookColumns() []pkg.TableColumn {
+	c := r.Columns
+	orangeWhen := func(found bool) pkg.Highlight {
+		if found {
+			return pkg.HighlightOrange
+		}
+		return pkg.NoHighlight
 	}
-
-	// Header
-	dt := time.Now().Format("2006-01-02")
-	_ = f.SetCellValue(sheetName, "A1",
-		fmt.Sprintf("Audit Bundle Report (Generated at %s)", dt))
-	_ = f.SetCellValue(sheetName, "A2", "Image used")
-	_ = f.SetCellValue(sheetName, "B2", r.Flags.IndexImage)
-	_ = f.SetCellValue(sheetName, "A3", "Image Index Create Date:")
-	_ = f.SetCellValue(sheetName, "B3", r.IndexImageInspect.Created)
-	_ = f.SetCellValue(sheetName, "A4", "Image Index ID:")
-	_ = f.SetCellValue(sheetName, "B4", r.IndexImageInspect.ID)
-
-	for k, v := range columns {
-		_ = f.SetCellValue(sheetName, fmt.Sprintf("%s5", k), v)
+	return []pkg.TableColumn{
+		{Name: "packageName", Header: "Package Name", Value: func(i int) interface{} { return c[i].PackageName }},
+		{Name: "repository", Header: "Repository", Value: func(i int) interface{} { return c[i].Repository }},
+		{Name: "ocpLabel", Header: "OCP Labels Version", Value: func(i int) interface{} { return c[i].OCPLabel }},
+		{Name: "ocpLabelIssues", Header: "OCP Label Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].OCPLabelIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].OCPLabelIssues) > 0)
+			}},
+		{Name: "maturity", Header: "Maturity", Value: func(i int) interface{} { return c[i].Maturity }},
+		{Name: "capabilities", Header: "Capabilities", Value: func(i int) interface{} { return c[i].Capabilities }},
+		{Name: "categories", Header: "Categories", Value: func(i int) interface{} { return c[i].Categories }},
+		{Name: "multipleArchitectures", Header: "Multiple Architectures", Value: func(i int) interface{} {
+			return strings.Join(c[i].MultipleArchitectures, ", ")
+		}},
+		{Name: "architectureMismatches", Header: "Architecture Mismatches",
+			Value: func(i int) interface{} { return len(c[i].ArchitectureMismatches) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].ArchitectureMismatches) > 0)
+			},
+			LinkTo: architecturesSheet,
+			Hidden: !r.Flags.CheckRelatedImages},
+		{Name: "metadataCompleteness", Header: "Metadata Completeness (%)",
+			Value: func(i int) interface{} { return c[i].MetadataCompleteness },
+			Highlight: func(i int) pkg.Highlight {
+				switch {
+				case c[i].MetadataCompleteness == 100:
+					return pkg.HighlightGreen
+				case c[i].MetadataCompleteness < minMetadataCompleteness:
+					return pkg.HighlightRed
+				}
+				return pkg.HighlightOrange
+			},
+			LinkTo: metadataSheet},
+		{Name: "metadataIssues", Header: "Metadata Issues",
+			Value: func(i int) interface{} { return len(c[i].MetadataIssues) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].MetadataIssues) > 0)
+			},
+			LinkTo: metadataSheet},
+		{Name: "certified", Header: "Certified", Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].Certified) }},
+		{Name: "kindsDeprecateAPIs", Header: "Kinds (Deprecated APIs on 1.22)",
+			Value: func(i int) interface{} { return strings.Join(c[i].KindsDeprecateAPIs, ", ") },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].KindsDeprecateAPIs) > 0)
+			},
+			LinkTo: deprecatedAPIsSheet},
+		{Name: "deprecateAPIsManifests", Header: "Suggestion API(s) manifests",
+			Value: func(i int) interface{} { return pkg.GenerateMessageWithDeprecatedAPIs(c[i].DeprecateAPIsManifests) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].DeprecateAPIsManifests) > 0)
+			},
+			LinkTo: deprecatedAPIsSheet},
+		{Name: "bundleName", Header: "Operator Bundle Name", Value: func(i int) interface{} { return c[i].BundleName }},
+		{Name: "bundleVersion", Header: "Operator Bundle Version",
+			Value: func(i int) interface{} { return c[i].BundleVersion }},
+		{Name: "defaultChannel", Header: "Default Channel", Value: func(i int) interface{} { return c[i].DefaultChannel }},
+		{Name: "bundleChannel", Header: "Bundle Channel", Value: func(i int) interface{} {
+			return strings.Join(pkg.GetUniqueValues(c[i].Channels), ", ")
+		}},
+		{Name: "bundleImageBuildDate", Header: "Build Date (from index image)",
+			Value: func(i int) interface{} { return c[i].BundleImageBuildDate }},
+		{Name: "bundleImagePath", Header: "Bundle Path", Value: func(i int) interface{} { return c[i].BundleImagePath }},
+		{Name: "bundleImageDigest", Header: "Bundle Image Digest",
+			Value: func(i int) interface{} { return c[i].BundleImageDigest }},
+		{Name: "bundleImagePinned", Header: "Bundle Path Pinned by Digest",
+			Value: func(i int) interface{} { return c[i].BundleImagePinned },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(c[i].BundleImagePinned == pkg.No)
+			}},
+		{Name: "relatedImages", Header: "Related Images",
+			Value: func(i int) interface{} { return len(c[i].RelatedImages) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].countRelatedImages(func(v models.RelatedImage) bool { return len(v.Error) > 0 }) > 0 {
+					return pkg.HighlightRed
+				}
+				return orangeWhen(c[i].countRelatedImages(func(v models.RelatedImage) bool {
+					return !v.Pinned || !v.InRelatedImages
+				}) > 0)
+			},
+			LinkTo: relatedImagesSheet},
+		{Name: "ownedAPIs", Header: "Owned APIs",
+			Value: func(i int) interface{} { return strings.Join(c[i].OwnedAPIs, "\n") }},
+		{Name: "requiredAPIs", Header: "Required APIs",
+			Value: func(i int) interface{} { return strings.Join(c[i].RequiredAPIs, "\n") }},
+		{Name: "requiredPackages", Header: "Required Packages",
+			Value: func(i int) interface{} { return strings.Join(c[i].RequiredPackages, "\n") }},
+		{Name: "bundleInconsistencies", Header: "Bundle Labels Inconsistencies",
+			Value: func(i int) interface{} { return len(c[i].BundleInconsistencies) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].BundleInconsistencies) > 0)
+			},
+			LinkTo: inconsistenciesSheet},
+		{Name: "hasWebhook", Header: "Has webhooks",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasWebhook) }},
+		{Name: "webhooks", Header: "Webhook Issues",
+			Value: func(i int) interface{} { return c[i].countWebhookIssues() },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(c[i].countWebhookIssues() > 0)
+			},
+			LinkTo: webhooksSheet},
+		{Name: "builder", Header: "Builder", Value: func(i int) interface{} { return c[i].Builder }},
+		{Name: "sdkVersion", Header: "SDK Version", Value: func(i int) interface{} { return c[i].SDKVersion }},
+		{Name: "projectLayout", Header: "Project Layout", Value: func(i int) interface{} { return c[i].ProjectLayout }},
+		{Name: "scorecardFailingTests", Header: "Scorecard Failing Tests",
+			Value: func(i int) interface{} { return len(c[i].ScorecardFailingTests) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].ScorecardFailingTests) > 0)
+			},
+			LinkTo: scorecardTestsSheet,
+			Hidden: r.Flags.DisableScorecard},
+		{Name: "scorecardSuggestions", Header: "Scorecard Suggestions",
+			Value: func(i int) interface{} { return len(c[i].ScorecardSuggestions) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].ScorecardSuggestions) > 0)
+			},
+			LinkTo: scorecardTestsSheet,
+			Hidden: r.Flags.DisableScorecard},
+		{Name: "scorecardErrors", Header: "Scorecard Errors",
+			Value: func(i int) interface{} { return len(c[i].ScorecardErrors) },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].ScorecardErrors) > 0 {
+					return pkg.HighlightRed
+				}
+				return pkg.NoHighlight
+			},
+			LinkTo: scorecardTestsSheet,
+			Hidden: r.Flags.DisableScorecard},
+		{Name: "validatorErrors", Header: "Validator Errors",
+			Value: func(i int) interface{} { return len(c[i].ValidatorErrors) },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].ValidatorErrors) > 0 {
+					return pkg.HighlightRed
+				}
+				return pkg.NoHighlight
+			},
+			LinkTo: validatorFindingsSheet,
+			Hidden: r.Flags.DisableValidators},
+		{Name: "validatorWarnings", Header: "Validator Warnings",
+			Value: func(i int) interface{} { return len(c[i].ValidatorWarnings) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].ValidatorWarnings) > 0)
+			},
+			LinkTo: validatorFindingsSheet,
+			Hidden: r.Flags.DisableValidators},
+		{Name: "invalidVersioning", Header: "Invalid Versioning",
+			Value: func(i int) interface{} { return c[i].InvalidVersioning },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].InvalidVersioning == pkg.GetYesOrNo(true) {
+					return pkg.HighlightOrange
+				}
+				return pkg.HighlightGreen
+			}},
+		{Name: "invalidSkipRange", Header: "Invalid SkipRange",
+			Value: func(i int) interface{} { return c[i].InvalidSkipRange },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].InvalidSkipRange == pkg.GetYesOrNo(true) {
+					return pkg.HighlightRed
+				}
+				return pkg.NoHighlight
+			}},
+		{Name: "isHeadOfChannel", Header: "Is head of channel",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].IsHeadOfChannel) }},
+		{Name: "headOfChannels", Header: "Head of Channels",
+			Value: func(i int) interface{} { return strings.Join(c[i].HeadOfChannels, ", ") }},
+		{Name: "skipRange", Header: "Skip Range", Value: func(i int) interface{} { return c[i].SkipRange }},
+		{Name: "skips", Header: "Skips", Value: func(i int) interface{} { return strings.Join(c[i].Skips, ", ") }},
+		{Name: "replace", Header: "Replace", Value: func(i int) interface{} { return c[i].Replace }},
+		{Name: "supportsAllNamespaces", Header: "Supports All Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].IsSupportingAllNamespaces)
+		}},
+		{Name: "supportSingleNamespaces", Header: "Supports Single Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].IsSupportingSingleNamespace)
+		}},
+		{Name: "supportsOwnNamespaces", Header: "Supports Own Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].IsSupportingOwnNamespaces)
+		}},
+		{Name: "supportsMultiNamespaces", Header: "Supports Multi Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].IsSupportingMultiNamespaces)
+		}},
+		{Name: "rbacRiskScore", Header: "RBAC Risk Score",
+			Value: func(i int) interface{} { return c[i].RBACRiskScore },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].RBACRiskScore >= highRBACRiskScore {
+					return pkg.HighlightRed
+				}
+				return orangeWhen(c[i].RBACRiskScore > 0)
+			},
+			LinkTo: rbacRisksSheet},
+		{Name: "rbacRisks", Header: "RBAC Risks",
+			Value: func(i int) interface{} { return len(c[i].RBACRisks) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].RBACRisks) > 0)
+			},
+			LinkTo: rbacRisksSheet},
+		{Name: "permissions", Header: "Permissions",
+			Value:  func(i int) interface{} { return len(c[i].Permissions) },
+			LinkTo: permissionChangesSheet},
+		{Name: "securityIssues", Header: "Deployment Security Issues",
+			Value: func(i int) interface{} { return len(c[i].SecurityIssues) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].hasSecurityIssue(privilegedIssue) {
+					return pkg.HighlightRed
+				}
+				return orangeWhen(len(c[i].SecurityIssues) > 0)
+			},
+			LinkTo: securitySheet},
+		{Name: "installModeIssues", Header: "Install Mode Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].InstallModeIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].InstallModeIssues) > 0)
+			}},
+		{Name: "infrastructure", Header: "Infrastructure Annotations",
+			Value: func(i int) interface{} { return c[i].Infrastructure }},
+		{Name: "infrastructureFeatures", Header: "Infrastructure Features",
+			Value: func(i int) interface{} { return strings.Join(c[i].InfrastructureFeatures.Names(), ", ") }},
+		{Name: "hasFeaturesAnnotations", Header: "Uses features.operators.openshift.io Annotations",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasFeaturesAnnotations) }},
+		{Name: "infrastructureIssues", Header: "Infrastructure Annotation Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].InfrastructureIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(len(c[i].InfrastructureIssues) > 0)
+			}},
+		{Name: "disconnectedReasons", Header: "Disconnected Readiness Issues",
+			Value: func(i int) interface{} { return len(c[i].DisconnectedReasons) },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].DisconnectedReasons) == 0 {
+					return pkg.HighlightGreen
+				}
+				return orangeWhen(c[i].InfrastructureFeatures.Disconnected)
+			},
+			LinkTo: disconnectedSheet},
+		{Name: "hasPossiblePerformIssues", Header: "Has possible performance issues",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasPossiblePerformIssues) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(c[i].HasPossiblePerformIssues)
+			}},
+		{Name: "maxOCPVersion", Header: "Max OCP Version", Value: func(i int) interface{} { return c[i].MaxOCPVersion }},
+		{Name: "hasCustomScorecardTests", Header: "Has custom Scorecards",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasCustomScorecardTests) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].HasCustomScorecardTests {
+					return pkg.HighlightGreen
+				}
+				return pkg.NoHighlight
+			}},
+		{Name: "errors", Header: "Audit Errors",
+			Value:  func(i int) interface{} { return len(c[i].AuditErrors) },
+			LinkTo: auditErrorsSheet},
 	}
+}
 
-	for k, v := range r.Columns {
-		line := k + 6
+func (r *Report) workbookDetails() []pkg.DetailSheet {
+	validators := pkg.DetailSheet{Name: validatorFindingsSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Level", "Detail"}}
+	scorecard := pkg.DetailSheet{Name: scorecardTestsSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Type", "Detail"}}
+	deprecated := pkg.DetailSheet{Name: deprecatedAPIsSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Kind", "Manifest Name"}}
+	auditErrors := pkg.DetailSheet{Name: auditErrorsSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Error"}}
+	relatedImages := pkg.DetailSheet{Name: relatedImagesSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Image", "Sources", "Pinned by Digest",
+			"In relatedImages", "Architectures", "Error"}}
+	inconsistencies := pkg.DetailSheet{Name: inconsistenciesSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Inconsistency"}}
+	disconnected := pkg.DetailSheet{Name: disconnectedSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Reason"}}
+	architectures := pkg.DetailSheet{Name: architecturesSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Mismatch"}}
+	webhooks := pkg.DetailSheet{Name: webhooksSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Webhook", "Type", "Deployment",
+			"Admission Review Versions", "Side Effects", "Failure Policy", "Container Port", "Target Port",
+			"Conversion CRDs", "Issues"}}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("A%d", line), v.PackageName); err != nil {
-			log.Errorf("to add packageName cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("B%d", line), v.Repository); err != nil {
-			log.Errorf("to add repository cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("C%d", line),
-			v.OCPLabel); err != nil {
-			log.Errorf("to add links cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("D%d", line), v.Maturity); err != nil {
-			log.Errorf("to add Maturity cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("E%d", line), v.Capabilities); err != nil {
-			log.Errorf("to add Capabilities cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("F%d", line), v.Categories); err != nil {
-			log.Errorf("to add Categories cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("G%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.MultipleArchitectures)); err != nil {
-			log.Errorf("to add MultipleArchitectures cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("H%d", line), pkg.GetYesOrNo(v.Certified)); err != nil {
-			log.Errorf("to add Certified cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("I%d", line), v.KindsDeprecateAPIs); err != nil {
-			log.Errorf("to add v cell value : %s", err)
-		}
-		if len(v.KindsDeprecateAPIs) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("I%d", line),
-				fmt.Sprintf("I%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("J%d", line), v.BundleName); err != nil {
-			log.Errorf("to add BundleName cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("K%d", line), v.BundleVersion); err != nil {
-			log.Errorf("to add BundleVersion cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("L%d", line), v.DefaultChannel); err != nil {
-			log.Errorf("to add DefaultChannel cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("M%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.Channels)); err != nil {
-			log.Errorf("to add Channels cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("N%d", line), v.BundleImageBuildDate); err != nil {
-			log.Errorf("to add BundleImageBuildDate cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("O%d", line), v.BundleImagePath); err != nil {
-			log.Errorf("to add BundleImagePath cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("P%d", line),
-			pkg.GetYesOrNo(v.HasWebhook)); err != nil {
-			log.Errorf("to add HasWebhook cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("Q%d", line), v.Builder); err != nil {
-			log.Errorf("to add Builder cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("R%d", line), v.SDKVersion); err != nil {
-			log.Errorf("to add SDKVersion cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("S%d", line), v.ProjectLayout); err != nil {
-			log.Errorf("to add ProjectLayout cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("T%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.ScorecardFailingTests)); err != nil {
-			log.Errorf("to add ScorecardFailingTests cell value : %s", err)
-		}
-		if len(v.ScorecardFailingTests) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("T%d", line),
-				fmt.Sprintf("T%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("U%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.ScorecardSuggestions)); err != nil {
-			log.Errorf("to add ScorecardSuggestions cell value : %s", err)
-		}
-		if len(v.ScorecardSuggestions) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("U%d", line),
-				fmt.Sprintf("U%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("V%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.ScorecardErrors)); err != nil {
-			log.Errorf("to add ScorecardErrors cell value : %s", err)
-		}
-		if len(v.ScorecardErrors) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("V%d", line),
-				fmt.Sprintf("V%d", line), styleRed)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("W%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.ValidatorErrors)); err != nil {
-			log.Errorf("to add ValidatorErrors cell value : %s", err)
-		}
-		if err := f.SetCellStyle(sheetName, fmt.Sprintf("W%d", line), fmt.Sprintf("W%d", line), styleWrapText); err != nil {
-			log.Errorf("unable to set style : %s", err)
-		}
-		if len(v.ValidatorErrors) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("W%d", line),
-				fmt.Sprintf("W%d", line), styleRed)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("X%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.ValidatorWarnings)); err != nil {
-			log.Errorf("to add ValidatorWarnings cell value : %s", err)
-		}
-		// format for the list of issues
-		if err := f.SetCellStyle(sheetName, fmt.Sprintf("X%d", line), fmt.Sprintf("X%d", line), styleWrapText); err != nil {
-			log.Errorf("unable to set style : %s", err)
-		}
-		if len(v.ValidatorWarnings) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("X%d", line),
-				fmt.Sprintf("X%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("Y%d", line),
-			v.InvalidVersioning); err != nil {
-			log.Errorf("to add InvalidVersioning cell value : %s", err)
-		}
-		if v.InvalidVersioning == pkg.GetYesOrNo(true) {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("Y%d", line),
-				fmt.Sprintf("Y%d", line), styleOrange)
-		} else {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("Y%d", line),
-				fmt.Sprintf("Y%d", line), styleGreen)
-		}
+	heads := pkg.DetailSheet{Name: channelHeadsSheet,
+		Headers: []string{"Package Name", "Channel", "Head", "Highest Version", "Issues"}}
+	for _, h := range ResolveChannelHeads(r.Columns) {
+		highlight := pkg.NoHighlight
+		if len(h.Issues) > 0 {
+			highlight = pkg.HighlightOrange
+		}
+		heads.Rows = append(heads.Rows, pkg.DetailRow{Owner: r.columnIndex(h.PackageName, h.Head),
+			Values:    []interface{}{h.PackageName, h.Channel, h.Head, h.LatestVersion, strings.Join(h.Issues, "\n")},
+			Highlight: highlight})
+	}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("Z%d", line),
-			v.InvalidSkipRange); err != nil {
-			log.Errorf("to add GetYesOrNo cell value : %s", err)
-		}
-		if v.InvalidSkipRange == pkg.GetYesOrNo(true) {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("Z%d", line),
-				fmt.Sprintf("AC%d", line), styleRed)
-		}
+	installModeChanges := pkg.DetailSheet{Name: installModeChangesSheet,
+		Headers: []string{"Package Name", "Channel", "From", "To", "Install Modes Added", "Install Modes Removed"}}
+	for _, v := range ResolveInstallModeChanges(r.Columns) {
+		highlight := pkg.NoHighlight
+		if len(v.Removed) > 0 {
+			highlight = pkg.HighlightOrange
+		}
+		installModeChanges.Rows = append(installModeChanges.Rows, pkg.DetailRow{
+			Owner: r.columnIndex(v.PackageName, v.To),
+			Values: []interface{}{v.PackageName, v.Channel, v.From, v.To, strings.Join(v.Added, ", "),
+				strings.Join(v.Removed, ", ")},
+			Highlight: highlight})
+	}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AA%d", line),
-			pkg.GetYesOrNo(v.IsHeadOfChannel)); err != nil {
-			log.Errorf("to add IsHeadOfChannel cell value: %s", err)
-		}
+	rbacRisks := pkg.DetailSheet{Name: rbacRisksSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Risk Score", "Risk"}}
+	metadata := pkg.DetailSheet{Name: metadataSheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Completeness (%)", "Issue"}}
+	security := pkg.DetailSheet{Name: securitySheet,
+		Headers: []string{"Package Name", "Operator Bundle Name", "Deployment", "Container", "Issue"}}
+	permissionChanges := pkg.DetailSheet{Name: permissionChangesSheet,
+		Headers: []string{"Package Name", "Channel", "From", "To", "Permissions Added"}}
+	for _, v := range ResolvePermissionChanges(r.Columns) {
+		var added []string
+		for _, p := range v.Added {
+			added = append(added, p.String())
+		}
+		permissionChanges.Rows = append(permissionChanges.Rows, pkg.DetailRow{
+			Owner:     r.columnIndex(v.PackageName, v.To),
+			Values:    []interface{}{v.PackageName, v.Channel, v.From, v.To, strings.Join(added, "\n")},
+			Highlight: pkg.HighlightOrange})
+	}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AB%d", line),
-			v.SkipRange); err != nil {
-			log.Errorf("to add SkipRange cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AC%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.Skips)); err != nil {
-			log.Errorf("to add HasGKVDependency cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AD%d", line),
-			v.Replace); err != nil {
-			log.Errorf("to add Replace cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AE%d", line),
-			pkg.GetYesOrNo(v.IsSupportingAllNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForAllNamespaces cell value : %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AF%d", line),
-			pkg.GetYesOrNo(v.IsSupportingSingleNamespace)); err != nil {
-			log.Errorf("to add HasSupportForAllNamespaces cell value : %s", err)
+	for i, v := range r.Columns {
+		for _, e := range v.ValidatorErrors {
+			validators.Rows = append(validators.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, "error", e}, Highlight: pkg.HighlightRed})
 		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AG%d", line),
-			pkg.GetYesOrNo(v.IsSupportingOwnNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForAllNamespaces cell value : %s", err)
+		for _, e := range v.ValidatorWarnings {
+			validators.Rows = append(validators.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, "warning", e}, Highlight: pkg.HighlightOrange})
 		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AH%d", line),
-			pkg.GetYesOrNo(v.IsSupportingMultiNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForAllNamespaces cell value : %s", err)
+		for _, e := range v.ScorecardFailingTests {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, "failing test", e}, Highlight: pkg.HighlightOrange})
 		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AI%d", line),
-			v.Infrastructure); err != nil {
-			log.Errorf("to add HasInfraAnnotation cell value : %s", err)
+		for _, e := range v.ScorecardErrors {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, "error", e}, Highlight: pkg.HighlightRed})
 		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AJ%d", line),
-			pkg.GetYesOrNo(v.HasPossiblePerformIssues)); err != nil {
-			log.Errorf("to add HasPossiblePerformIssues cell value : %s", err)
+		for _, e := range v.ScorecardSuggestions {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, "suggestion", e}})
 		}
-		if v.HasPossiblePerformIssues {
-			if err := f.AddComment(sheetName, fmt.Sprintf("AJ%d", line),
-				`{"author":"Audit: ","text":"Project supports Disconnected Mode and Multiple Architectures"}`); err != nil {
-				log.Errorf("to add comment for HasPossiblePerformIssues: %s", err)
-			}
 
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("AJ%d", line),
-				fmt.Sprintf("AJ%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AK%d", line),
-			pkg.GenerateMessageWithDeprecatedAPIs(v.DeprecateAPIsManifests)); err != nil {
-			log.Errorf("to add DeprecateAPIsManifests cell value : %s", err)
-		}
-		if len(v.DeprecateAPIsManifests) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("AK%d", line),
-				fmt.Sprintf("AL%d", line), styleOrange)
+		kinds := make([]string, 0, len(v.DeprecateAPIsManifests))
+		for k := range v.DeprecateAPIsManifests {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		for _, k := range kinds {
+			for _, name := range v.DeprecateAPIsManifests[k] {
+				deprecated.Rows = append(deprecated.Rows, pkg.DetailRow{Owner: i,
+					Values: []interface{}{v.PackageName, v.BundleName, k, name}, Highlight: pkg.HighlightOrange})
+			}
 		}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AL%d", line), v.MaxOCPVersion); err != nil {
-			log.Errorf("to add MaxOCPVersion cell value : %s", err)
+		for _, e := range v.AuditErrors {
+			auditErrors.Rows = append(auditErrors.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e}})
 		}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AM%d", line),
-			pkg.GetYesOrNo(v.HasCustomScorecardTests)); err != nil {
-			log.Errorf("to add HasCustomScorecardTests cell value: %s", err)
+		for _, e := range v.BundleInconsistencies {
+			inconsistencies.Rows = append(inconsistencies.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e}, Highlight: pkg.HighlightOrange})
+		}
+		for _, e := range v.DisconnectedReasons {
+			disconnected.Rows = append(disconnected.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e}})
+		}
+		for _, e := range v.ArchitectureMismatches {
+			architectures.Rows = append(architectures.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e}, Highlight: pkg.HighlightOrange})
+		}
+		for _, e := range v.RBACRisks {
+			highlight := pkg.HighlightOrange
+			if v.RBACRiskScore >= highRBACRiskScore {
+				highlight = pkg.HighlightRed
+			}
+			rbacRisks.Rows = append(rbacRisks.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, v.RBACRiskScore, e}, Highlight: highlight})
 		}
-
-		if v.HasCustomScorecardTests {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("AM%d", line),
-				fmt.Sprintf("AM%d", line), styleGreen)
+		for _, e := range v.MetadataIssues {
+			metadata.Rows = append(metadata.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, v.MetadataCompleteness, e}})
 		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("AN%d", line), v.AuditErrors); err != nil {
-			log.Errorf("to add AuditErrors cell value : %s", err)
+		for _, e := range v.SecurityIssues {
+			highlight := pkg.HighlightOrange
+			if e.Issue == privilegedIssue {
+				highlight = pkg.HighlightRed
+			}
+			security.Rows = append(security.Rows, pkg.DetailRow{Owner: i,
+				Values:    []interface{}{v.PackageName, v.BundleName, e.Deployment, e.Container, e.Issue},
+				Highlight: highlight})
+		}
+		for _, e := range v.Webhooks {
+			highlight := pkg.NoHighlight
+			if len(e.Issues) > 0 {
+				highlight = pkg.HighlightOrange
+			}
+			webhooks.Rows = append(webhooks.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e.Name, e.Type, e.DeploymentName,
+					strings.Join(e.AdmissionReviewVersions, ", "), e.SideEffects, e.FailurePolicy, e.ContainerPort,
+					e.TargetPort, strings.Join(e.ConversionCRDs, ", "), strings.Join(e.Issues, "\n")},
+				Highlight: highlight})
+		}
+		for _, e := range v.RelatedImages {
+			highlight := pkg.NoHighlight
+			if len(e.Error) > 0 {
+				highlight = pkg.HighlightRed
+			} else if !e.Pinned || !e.InRelatedImages {
+				highlight = pkg.HighlightOrange
+			}
+			relatedImages.Rows = append(relatedImages.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.BundleName, e.Image, strings.Join(e.Sources, ", "),
+					pkg.GetYesOrNo(e.Pinned), pkg.GetYesOrNo(e.InRelatedImages),
+					strings.Join(e.Architectures, ", "), e.Error},
+				Highlight: highlight})
 		}
 	}
 
-	// Remove the scorecard columns when that is disable
-	if r.Flags.DisableScorecard {
-		if err := f.SetColVisible(sheetName, "T", false); err != nil {
-			log.Errorf("unable to remove scorecard columns : %s", err)
-		}
-		if err := f.SetColVisible(sheetName, "U", false); err != nil {
-			log.Errorf("unable to remove scorecard columns : %s", err)
-		}
-		if err := f.SetColVisible(sheetName, "V", false); err != nil {
-			log.Errorf("unable to remove scorecard columns : %s", err)
-		}
+	details := []pkg.DetailSheet{deprecated, metadata, heads, inconsistencies, installModeChanges, webhooks, rbacRisks,
+		permissionChanges, security, relatedImages}
+	if r.Flags.CheckRelatedImages {
+		details = append(details, architectures)
+	}
+	details = append(details, disconnected, auditErrors)
+	if !r.Flags.DisableScorecard {
+		details = append([]pkg.DetailSheet{scorecard}, details...)
+	}
+	if !r.Flags.DisableValidators {
+		details = append([]pkg.DetailSheet{validators}, details...)
 	}
+	return details
+}
 
-	// Remove the validators columns when that is disable
-	if r.Flags.DisableValidators {
-		if err := f.SetColVisible(sheetName, "W", false); err != nil {
-			log.Errorf("unable to remove validator columns : %s", err)
-		}
-		if err := f.SetColVisible(sheetName, "X", false); err != nil {
-			log.Errorf("unable to remove validator columns : %s", err)
+// columnIndex returns the index of the column of the bundle or -1 when the bundle is not in the report
+func (r *Report) columnIndex(packageName, bundleName string) int {
+	for i, v := range r.Columns {
+		if v.PackageName == packageName && v.BundleName == bundleName {
+			return i
 		}
 	}
+	return -1
+}
 
-	if err := f.AddTable(sheetName, "A5", "AN5", pkg.TableFormat); err != nil {
-		log.Errorf("unable to add table format : %s", err)
+func countChannelHeadsWithIssues(columns []Column) int {
+	count := 0
+	for _, v := range ResolveChannelHeads(columns) {
+		if len(v.Issues) > 0 {
+			count++
+		}
 	}
+	return count
+}
 
-	reportFilePath := filepath.Join(r.Flags.OutputPath,
-		pkg.GetReportName(r.Flags.IndexImage, "bundles", "xlsx"))
-
-	if err := f.SaveAs(reportFilePath); err != nil {
-		return err
+// countInstallModesRemoved returns the number of upgrades which remove the support for an install mode
+func countInstallModesRemoved(columns []Column) int {
+	count := 0
+	for _, v := range ResolveInstallModeChanges(columns) {
+		if len(v.Removed) > 0 {
+			count++
+		}
 	}
-	return nil
+	return count
 }
 
-func (r *Report) writeJSON() error {
-	data, err := json.Marshal(r)
-	if err != nil {
-		return err
+// Kind returns the type of the report
+func (r *Report) Kind() string {
+	return "bundles"
+}
+
+// ImageName returns the image audited or the name of the bundle directory when it was used instead
+func (r *Report) ImageName() string {
+	switch {
+	case len(r.Flags.BundleImage) > 0:
+		return r.Flags.BundleImage
+	case len(r.Flags.IndexImage) == 0 && len(r.Flags.BundleDir) > 0:
+		return filepath.Base(r.Flags.BundleDir)
 	}
+	return r.Flags.IndexImage
+}
 
-	const reportType = "bundles"
-	return pkg.WriteJSON(data, r.Flags.IndexImage, r.Flags.OutputPath, reportType)
+// OutputPath returns the directory where the report should be written
+func (r *Report) OutputPath() string {
+	return r.Flags.OutputPath
 }
@@ -15,7 +15,10 @@
 package bundles
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/operator-framework/audit/pkg"
 )
 
 func TestBuildQuery(t *testing.T) {
@@ -23,10 +26,11 @@ func TestBuildQuery(t *testing.T) {
 		report Data
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name     string
+		args     args
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
 	}{
 		{
 			name: "should build only the select when has not flags values",
@@ -48,10 +52,33 @@ func TestBuildQuery(t *testing.T) {
 			}}},
 			want: "SELECT o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips FROM operatorbundle o, channel c WHERE c.head_operatorbundle_name == o.name LIMIT 3",
 		},
+		{
+			name:     "should keep head only when filter by name",
+			args:     args{report: Data{Flags: BindFlags{HeadOnly: true, Filter: "etcd", Limit: int32(2)}}},
+			want:     "SELECT o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips FROM operatorbundle o, channel c WHERE c.head_operatorbundle_name == o.name AND c.package_name LIKE ? LIMIT 2",
+			wantArgs: []interface{}{"%etcd%"},
+		},
+		{
+			name:     "should build sql with the package names and channel",
+			args:     args{report: Data{Flags: BindFlags{PackageList: "packages.txt", Channel: "stable"}, PackageNames: []string{"etcd", "mongodb"}}},
+			want:     "SELECT DISTINCT o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips FROM operatorbundle o, channel_entry e WHERE e.operatorbundle_name == o.name AND e.package_name IN (?,?) AND e.channel_name = ?",
+			wantArgs: []interface{}{"etcd", "mongodb", "stable"},
+		},
+		{
+			name: "should build sql for the default channel head only",
+			args: args{report: Data{Flags: BindFlags{HeadOnly: true, DefaultChannelOnly: true}}},
+			want: "SELECT o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips FROM operatorbundle o, channel c, package p WHERE c.head_operatorbundle_name == o.name AND p.name == c.package_name AND p.default_channel == c.name",
+		},
+		{
+			name:     "should build sql with the properties and without limit when has version range",
+			args:     args{report: Data{Flags: BindFlags{Properties: []string{"olm.maxOpenShiftVersion", "olm.package=etcd"}, VersionRange: ">=1.0.0", Limit: int32(3)}}},
+			want:     "SELECT o.name, o.csv, o.bundlepath, o.version, o.skiprange, o.replaces, o.skips FROM operatorbundle o WHERE EXISTS (SELECT 1 FROM properties pr WHERE pr.operatorbundle_name == o.name AND pr.type == ?) AND EXISTS (SELECT 1 FROM properties pr WHERE pr.operatorbundle_name == o.name AND pr.type == ? AND pr.value LIKE ?)",
+			wantArgs: []interface{}{"olm.maxOpenShiftVersion", "olm.package", "%etcd%"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.args.report.BuildBundlesQuery()
+			got, gotArgs, err := tt.args.report.BuildBundlesQuery()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildBundlesQuery() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -59,6 +86,30 @@ func TestBuildQuery(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("BuildBundlesQuery() got = %v, want %v", got, tt.want)
 			}
+			if len(gotArgs) > 0 || len(tt.wantArgs) > 0 {
+				if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+					t.Errorf("BuildBundlesQuery() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+				}
+			}
 		})
 	}
 }
+
+func TestWorkbookColumnsAreJSONFields(t *testing.T) {
+	fields := map[string]bool{}
+	for _, name := range pkg.JSONFieldNames(Column{}) {
+		fields[name] = true
+	}
+	columns := map[string]bool{}
+	for _, column := range (&Report{}).workbookColumns() {
+		columns[column.Name] = true
+		if !fields[column.Name] {
+			t.Errorf("workbookColumns() has the column %s which is not a JSON field of Column", column.Name)
+		}
+	}
+	for name := range fields {
+		if !columns[name] {
+			t.Errorf("the JSON field %s of Column has no column in workbookColumns()", name)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+// Security issues checked in the pod templates of the deployments of the CSV
+const (
+	privilegedIssue       = "the container is privileged"
+	runAsNonRootIssue     = "runAsNonRoot is not set to true"
+	readOnlyRootFSIssue   = "readOnlyRootFilesystem is not set to true"
+	hostNetworkIssue      = "hostNetwork is enabled"
+	hostPathIssue         = "a hostPath volume is used"
+	resourceRequestsIssue = "resource requests are not informed"
+	resourceLimitsIssue   = "resource limits are not informed"
+	livenessProbeIssue    = "liveness probe is not informed"
+	readinessProbeIssue   = "readiness probe is not informed"
+	latestTagIssue        = "the image is referenced by the latest tag or without tag"
+)
+
+// podLevel is the container informed for the issues of the pod template
+const podLevel = "(pod)"
+
+// SecurityIssue defines an issue with the security posture of a deployment of the CSV
+type SecurityIssue struct {
+	Deployment string `json:"deployment"`
+	Container  string `json:"container"`
+	Issue      string `json:"issue"`
+}
+
+// CheckSecurity sets the issues found with the security posture of the pod templates of the deployments
+// of the install strategy of the CSV
+func (c *Column) CheckSecurity(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+	for _, deployment := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		add := func(container, issue string) {
+			c.SecurityIssues = append(c.SecurityIssues, SecurityIssue{Deployment: deployment.Name,
+				Container: container, Issue: issue})
+		}
+		podSpec := deployment.Spec.Template.Spec
+		if podSpec.HostNetwork {
+			add(podLevel, hostNetworkIssue)
+		}
+		for _, v := range podSpec.Volumes {
+			if v.HostPath != nil {
+				add(podLevel, hostPathIssue)
+				break
+			}
+		}
+
+		podRunAsNonRoot := podSpec.SecurityContext != nil && isTrue(podSpec.SecurityContext.RunAsNonRoot)
+		for _, container := range podSpec.InitContainers {
+			for _, issue := range checkContainer(container, podRunAsNonRoot, false) {
+				add(container.Name, issue)
+			}
+		}
+		for _, container := range podSpec.Containers {
+			for _, issue := range checkContainer(container, podRunAsNonRoot, true) {
+				add(container.Name, issue)
+			}
+		}
+	}
+}
+
+// checkContainer returns the security issues of the container. The probes are checked only for the
+// containers which are not init containers.
+func checkContainer(container corev1.Container, podRunAsNonRoot, checkProbes bool) []string {
+	var issues []string
+	sc := container.SecurityContext
+	if sc != nil && isTrue(sc.Privileged) {
+		issues = append(issues, privilegedIssue)
+	}
+	runAsNonRoot := podRunAsNonRoot
+	if sc != nil && sc.RunAsNonRoot != nil {
+		runAsNonRoot = *sc.RunAsNonRoot
+	}
+	if !runAsNonRoot {
+		issues = append(issues, runAsNonRootIssue)
+	}
+	if sc == nil || !isTrue(sc.ReadOnlyRootFilesystem) {
+		issues = append(issues, readOnlyRootFSIssue)
+	}
+	if len(container.Resources.Requests) == 0 {
+		issues = append(issues, resourceRequestsIssue)
+	}
+	if len(container.Resources.Limits) == 0 {
+		issues = append(issues, resourceLimitsIssue)
+	}
+	if checkProbes && container.LivenessProbe == nil {
+		issues = append(issues, livenessProbeIssue)
+	}
+	if checkProbes && container.ReadinessProbe == nil {
+		issues = append(issues, readinessProbeIssue)
+	}
+	if usesLatestTag(container.Image) {
+		issues = append(issues, latestTagIssue)
+	}
+	return issues
+}
+
+// usesLatestTag returns true when the image is referenced by the latest tag or without tag and digest
+func usesLatestTag(image string) bool {
+	if len(image) == 0 {
+		return false
+	}
+	_, reference := pkg.SplitImageTag(image)
+	return reference == "latest" || len(reference) == 0
+}
+
+// hasSecurityIssue returns true when the issue was found in any deployment of the bundle
+func (c *Column) hasSecurityIssue(issue string) bool {
+	for _, v := range c.SecurityIssues {
+		if v.Issue == issue {
+			return true
+		}
+	}
+	return false
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// defaultWebhookContainerPort is the container port used by OLM when the webhook does not inform one
+const defaultWebhookContainerPort = 443
+
+// Webhook defines the data of a webhook definition of the CSV
+type Webhook struct {
+	Name                    string   `json:"name"`
+	Type                    string   `json:"type"`
+	DeploymentName          string   `json:"deploymentName,omitempty"`
+	AdmissionReviewVersions []string `json:"admissionReviewVersions,omitempty"`
+	SideEffects             string   `json:"sideEffects,omitempty"`
+	FailurePolicy           string   `json:"failurePolicy,omitempty"`
+	ContainerPort           int32    `json:"containerPort,omitempty"`
+	TargetPort              string   `json:"targetPort,omitempty"`
+	ConversionCRDs          []string `json:"conversionCRDs,omitempty"`
+	Issues                  []string `json:"issues,omitempty"`
+}
+
+// AddWebhooks sets the webhooks defined in the CSV and the issues found with their configuration.
+// It should be called after the install modes were set.
+func (c *Column) AddWebhooks(csv *v1alpha1.ClusterServiceVersion) {
+	if csv == nil {
+		return
+	}
+
+	deployments := make(map[string]bool)
+	for _, v := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		deployments[v.Name] = true
+	}
+	ownedCRDs := make(map[string]bool)
+	for _, v := range csv.Spec.CustomResourceDefinitions.Owned {
+		ownedCRDs[v.Name] = true
+	}
+
+	for _, v := range csv.Spec.WebhookDefinitions {
+		webhook := Webhook{
+			Name:                    v.GenerateName,
+			Type:                    string(v.Type),
+			DeploymentName:          v.DeploymentName,
+			AdmissionReviewVersions: v.AdmissionReviewVersions,
+			ContainerPort:           v.ContainerPort,
+			ConversionCRDs:          v.ConversionCRDs,
+		}
+		if webhook.ContainerPort == 0 {
+			webhook.ContainerPort = defaultWebhookContainerPort
+		}
+		if v.TargetPort != nil {
+			webhook.TargetPort = v.TargetPort.String()
+		}
+		if v.SideEffects != nil {
+			webhook.SideEffects = string(*v.SideEffects)
+		}
+		if v.FailurePolicy != nil {
+			webhook.FailurePolicy = string(*v.FailurePolicy)
+		}
+		webhook.Issues = checkWebhook(v, deployments, ownedCRDs)
+		c.Webhooks = append(c.Webhooks, webhook)
+	}
+}
+
+// checkWebhook returns the issues found with the webhook definition. Note that the webhooks defined without the
+// AllNamespaces install mode are reported once per bundle with the install mode issues.
+func checkWebhook(webhook v1alpha1.WebhookDescription, deployments, ownedCRDs map[string]bool) []string {
+	var issues []string
+	if len(webhook.DeploymentName) == 0 {
+		issues = append(issues, "deploymentName is not informed")
+	} else if !deployments[webhook.DeploymentName] {
+		issues = append(issues, fmt.Sprintf("the deployment %s is not defined in the install strategy",
+			webhook.DeploymentName))
+	}
+
+	switch webhook.Type {
+	case v1alpha1.ValidatingAdmissionWebhook, v1alpha1.MutatingAdmissionWebhook:
+		if len(webhook.AdmissionReviewVersions) == 0 {
+			issues = append(issues, "admissionReviewVersions is not informed")
+		}
+		if webhook.SideEffects == nil {
+			issues = append(issues, "sideEffects is not informed")
+		} else if *webhook.SideEffects != admissionregistrationv1.SideEffectClassNone &&
+			*webhook.SideEffects != admissionregistrationv1.SideEffectClassNoneOnDryRun {
+			issues = append(issues, fmt.Sprintf("sideEffects %s is not allowed by admissionregistration.k8s.io/v1, "+
+				"use None or NoneOnDryRun", *webhook.SideEffects))
+		}
+		if len(webhook.ConversionCRDs) > 0 {
+			issues = append(issues, fmt.Sprintf("conversionCRDs are ignored for the type %s", webhook.Type))
+		}
+	case v1alpha1.ConversionWebhook:
+		if len(webhook.ConversionCRDs) == 0 {
+			issues = append(issues, "conversionCRDs is not informed")
+		}
+		for _, crd := range webhook.ConversionCRDs {
+			if !ownedCRDs[crd] {
+				issues = append(issues, fmt.Sprintf("the conversion CRD %s is not owned by the CSV", crd))
+			}
+		}
+	default:
+		issues = append(issues, fmt.Sprintf("the type %s is not supported by OLM, use one of %s", webhook.Type,
+			strings.Join([]string{string(v1alpha1.ValidatingAdmissionWebhook),
+				string(v1alpha1.MutatingAdmissionWebhook), string(v1alpha1.ConversionWebhook)}, ", ")))
+	}
+	return issues
+}
+
+// countWebhookIssues returns the number of issues found with the webhooks of the bundle
+func (c *Column) countWebhookIssues() int {
+	count := 0
+	for _, v := range c.Webhooks {
+		count += len(v.Issues)
+	}
+	return count
+}
@@ -23,6 +23,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 type Data struct {
@@ -48,6 +49,7 @@ func (d *Data) PrepareReport() Report {
 
 	dt := time.Now().Format("2006-01-02")
 	finalReport.GenerateAt = dt
+	finalReport.Metadata = pkg.NewReportMetadata(finalReport.Kind(), d.Flags.IndexImage, d.IndexImageInspect, nil)
 
 	if len(allColumns) == 0 {
 		log.Fatal("No data was found for the criteria informed. " +
@@ -59,26 +61,7 @@ func (d *Data) PrepareReport() Report {
 
 func (d *Data) OutputReport() error {
 	report := d.PrepareReport()
-	switch d.Flags.OutputFormat {
-	case pkg.Xls:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-	case pkg.JSON:
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	case pkg.All:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("invalid output format : %s", d.Flags.OutputFormat)
-	}
-	return nil
+	return writers.Output(&report, d.Flags.OutputFormat)
 }
 
 func (d *Data) BuildChannelsQuery() (string, error) {
@@ -15,14 +15,9 @@
 package channels
 
 import (
-	"encoding/json"
 	"fmt"
-	"path/filepath"
 	"time"
 
-	"github.com/360EntSecGroup-Skylar/excelize/v2"
-	log "github.com/sirupsen/logrus"
-
 	"github.com/operator-framework/audit/pkg"
 )
 
@@ -31,108 +26,145 @@ type Report struct {
 	Flags             BindFlags `json:"flags"`
 	IndexImageInspect pkg.DockerInspectManifest
 	GenerateAt        string
+	Metadata          pkg.ReportMetadata `json:"metadata"`
 }
 
-//todo: fix the complexity
-//nolint:gocyclo
-func (r *Report) writeXls() error {
-	const sheetName = "Sheet1"
-	f := excelize.NewFile()
+const auditErrorsSheet = "Audit Errors"
 
-	styleOrange, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#ec8f1c",
-		},
-	})
+// Migrate checks that the schema version of the report is supported and fills the metadata of the
+// reports generated before it was added
+func (r *Report) Migrate() error {
+	return r.Metadata.Migrate(r.Kind(), r.Flags.IndexImage, r.GenerateAt)
+}
 
-	columns := map[string]string{
-		"A": "Package Name",
-		"B": "Channel Name",
-		"C": "Is using skips",
-		"D": "Is using skipRange",
-		"E": "Is Following Name Convention",
-		"F": "Has Invalid Versioning",
-		"G": "Has Invalid SkipRange",
-		"H": "Issues (To process this report)",
-	}
+// Schema returns the JSON Schema of the report
+func Schema() *pkg.JSONSchema {
+	s := pkg.NewJSONSchema("Audit Channels Report", Report{})
+	s.Properties["metadata"].Properties["schemaVersion"].Enum = []string{pkg.SchemaVersion}
+	return s
+}
 
-	// Header
+// Workbook returns the tabular representation of the report used by the writers
+func (r *Report) Workbook() *pkg.Workbook {
 	dt := time.Now().Format("2006-01-02")
-	_ = f.SetCellValue(sheetName, "A1",
-		fmt.Sprintf("Audit Channels Report (Generated at %s)", dt))
-	_ = f.SetCellValue(sheetName, "A2", "Image used")
-	_ = f.SetCellValue(sheetName, "B2", r.Flags.IndexImage)
-	_ = f.SetCellValue(sheetName, "A3", "Image Index Create Date:")
-	_ = f.SetCellValue(sheetName, "B3", r.IndexImageInspect.Created)
-	_ = f.SetCellValue(sheetName, "A4", "Image Index ID:")
-	_ = f.SetCellValue(sheetName, "B4", r.IndexImageInspect.ID)
-
-	for k, v := range columns {
-		_ = f.SetCellValue(sheetName, fmt.Sprintf("%s5", k), v)
+	return &pkg.Workbook{
+		Title:     fmt.Sprintf("Audit Channels Report (Generated at %s)", dt),
+		Metadata:  r.workbookMetadata(),
+		Counts:    r.workbookCounts(),
+		Charts:    r.workbookCharts(),
+		TableName: "Channels",
+		Columns:   r.workbookColumns(),
+		Rows:      len(r.Columns),
+		Details:   r.workbookDetails(),
 	}
+}
 
-	for k, v := range r.Columns {
-		line := k + 6
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("A%d", line), v.PackageName); err != nil {
-			log.Errorf("to add packageName cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("B%d", line), v.ChannelName); err != nil {
-			log.Errorf("to add packageName cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("C%d", line), pkg.GetYesOrNo(v.IsUsingSkips)); err != nil {
-			log.Errorf("to add packageName cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("D%d", line), pkg.GetYesOrNo(v.IsUsingSkipRange)); err != nil {
-			log.Errorf("to add IsUsingSkipRange cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("E%d", line),
-			pkg.GetYesOrNo(v.IsFollowingNameConvention)); err != nil {
-			log.Errorf("to add HasIvalidVersioning cell value: %s", err)
-		}
-		if !v.IsFollowingNameConvention {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("E%d", line),
-				fmt.Sprintf("E%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("F%d", line), pkg.GetYesOrNo(v.HasInvalidVersioning)); err != nil {
-			log.Errorf("to add HasIvalidVersioning cell value: %s", err)
-		}
-		if v.HasInvalidVersioning {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("F%d", line),
-				fmt.Sprintf("F%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("G%d", line), pkg.GetYesOrNo(v.HasInvalidSkipRange)); err != nil {
-			log.Errorf("to add HasInvalidSkipRange cell value: %s", err)
-		}
-		if v.HasInvalidSkipRange {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("G%d", line),
-				fmt.Sprintf("G%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("H%d", line), v.AuditErrors); err != nil {
-			log.Errorf("to add AuditErrors cell value: %s", err)
-		}
-
+func (r *Report) workbookMetadata() []pkg.SummaryField {
+	return []pkg.SummaryField{
+		{Name: "Image used", Value: r.Flags.IndexImage},
+		{Name: "Image Index Create Date", Value: r.IndexImageInspect.Created},
+		{Name: "Image Index ID", Value: r.IndexImageInspect.ID},
+		{Name: "Image Index Digest", Value: r.Metadata.CatalogDigest},
+		{Name: "Generated at", Value: r.Metadata.GeneratedAt},
+		{Name: "Audit Tool Version", Value: r.Metadata.Tool.Version},
+		{Name: "Filter", Value: r.Flags.Filter},
 	}
+}
 
-	if err := f.AddTable(sheetName, "A5", "H5", pkg.TableFormat); err != nil {
-		log.Errorf("to set table format : %s", err)
+func (r *Report) workbookCounts() []pkg.SummaryField {
+	rows := len(r.Columns)
+	var packages []string
+	for _, v := range r.Columns {
+		packages = append(packages, v.PackageName)
 	}
+	return []pkg.SummaryField{
+		{Name: "Packages", Value: len(pkg.GetUniqueValues(packages))},
+		{Name: "Channels", Value: rows},
+		{Name: "Channels with invalid versioning", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].HasInvalidVersioning
+		})},
+		{Name: "Channels with invalid skipRange", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].HasInvalidSkipRange
+		})},
+	}
+}
 
-	reportFilePath := filepath.Join(r.Flags.OutputPath,
-		pkg.GetReportName(r.Flags.IndexImage, "channels", "xlsx"))
+func (r *Report) workbookCharts() []pkg.SummaryChart {
+	rows := len(r.Columns)
+	following := pkg.CountRowsWith(rows, func(i int) bool {
+		return r.Columns[i].IsFollowingNameConvention
+	})
+	return []pkg.SummaryChart{
+		{
+			Title: "Channel naming convention",
+			Type:  "pie",
+			Series: []pkg.SummaryField{
+				{Name: "Following", Value: following},
+				{Name: "Not following", Value: rows - following},
+			},
+		},
+	}
+}
 
-	if err := f.SaveAs(reportFilePath); err != nil {
-		return err
+func (r *Report) workbookColumns() []pkg.TableColumn {
+	c := r.Columns
+	orangeWhen := func(found bool) pkg.Highlight {
+		if found {
+			return pkg.HighlightOrange
+		}
+		return pkg.NoHighlight
+	}
+	return []pkg.TableColumn{
+		{Header: "Package Name", Value: func(i int) interface{} { return c[i].PackageName }},
+		{Header: "Channel Name", Value: func(i int) interface{} { return c[i].ChannelName }},
+		{Header: "Is using skips", Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].IsUsingSkips) }},
+		{Header: "Is using skipRange", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].IsUsingSkipRange)
+		}},
+		{Header: "Is Following Name Convention",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].IsFollowingNameConvention) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(!c[i].IsFollowingNameConvention)
+			}},
+		{Header: "Has Invalid Versioning",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasInvalidVersioning) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(c[i].HasInvalidVersioning)
+			}},
+		{Header: "Has Invalid SkipRange",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasInvalidSkipRange) },
+			Highlight: func(i int) pkg.Highlight {
+				return orangeWhen(c[i].HasInvalidSkipRange)
+			}},
+		{Header: "Audit Errors",
+			Value:  func(i int) interface{} { return len(c[i].AuditErrors) },
+			LinkTo: auditErrorsSheet},
 	}
-	return nil
 }
 
-func (r *Report) writeJSON() error {
-	data, err := json.Marshal(r)
-	if err != nil {
-		return err
+func (r *Report) workbookDetails() []pkg.DetailSheet {
+	auditErrors := pkg.DetailSheet{Name: auditErrorsSheet,
+		Headers: []string{"Package Name", "Channel Name", "Error"}}
+	for i, v := range r.Columns {
+		for _, e := range v.AuditErrors {
+			auditErrors.Rows = append(auditErrors.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, v.ChannelName, e}})
+		}
 	}
+	return []pkg.DetailSheet{auditErrors}
+}
+
+// Kind returns the type of the report
+func (r *Report) Kind() string {
+	return "channels"
+}
+
+// ImageName returns the index image audited
+func (r *Report) ImageName() string {
+	return r.Flags.IndexImage
+}
 
-	const reportType = "channels"
-	return pkg.WriteJSON(data, r.Flags.IndexImage, r.Flags.OutputPath, reportType)
+// OutputPath returns the directory where the report should be written
+func (r *Report) OutputPath() string {
+	return r.Flags.OutputPath
 }
@@ -17,7 +17,8 @@ package custom
 import (
 	"encoding/json"
 
-	"github.com/blang/semver"
+	log "github.com/sirupsen/logrus"
+
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/reports/bundles"
 )
@@ -32,6 +33,9 @@ func ParseBundlesJSONReport() (bundles.Report, error) {
 	if err = json.Unmarshal(byteValue, &bundlesReport); err != nil {
 		return bundles.Report{}, err
 	}
+	if err = bundlesReport.Migrate(); err != nil {
+		return bundles.Report{}, err
+	}
 	return bundlesReport, err
 }
 
@@ -45,17 +49,17 @@ func GetMaxOCPValue(b bundles.Column) string {
 	return maxValue
 }
 
-// GetTheLatestBundleVersion returns the latest/upper semversion
+// GetTheLatestBundleVersion returns the latest/upper semversion. The bundles with invalid versions are logged
+// and not compared, as done to resolve the channel heads
 func GetTheLatestBundleVersion(bundlesFromChannel []bundles.Column) string {
-	var latestVersion string
-	for _, v := range bundlesFromChannel {
-		bundleVersionSemVer, _ := semver.ParseTolerant(v.BundleVersion)
-		latestVersionSemVer, _ := semver.ParseTolerant(latestVersion)
-		if bundleVersionSemVer.GT(latestVersionSemVer) {
-			latestVersion = v.BundleVersion
-		}
+	latest, issues := bundles.LatestBundleVersion(bundlesFromChannel)
+	for _, issue := range issues {
+		log.Warn(issue)
+	}
+	if latest < 0 {
+		return ""
 	}
-	return latestVersion
+	return bundlesFromChannel[latest].BundleVersion
 }
 
 // GetQtLatestVersionChannelsState returns the qtd. of channels which are OK and configured with max ocp version
@@ -120,23 +124,21 @@ func MapBundlesPerPackage(bundlesReport bundles.Report) map[string][]bundles.Col
 	return mapPackagesWithBundles
 }
 
+// GetHeadOfChannels returns the head of the channels of the package resolved from its replaces graph
 func GetHeadOfChannels(bundlesOfPackage []bundles.Column) []bundles.Column {
 	var headOfChannels []bundles.Column
-	qtdHeads := 0
-	for _, v := range bundlesOfPackage {
-		if v.IsHeadOfChannel {
-			qtdHeads++
-			headOfChannels = append(headOfChannels, v)
+	found := map[string]bool{}
+	for _, head := range bundles.ResolveChannelHeads(bundlesOfPackage) {
+		if found[head.Head] {
+			continue
+		}
+		for _, v := range bundlesOfPackage {
+			if v.BundleName == head.Head {
+				found[head.Head] = true
+				headOfChannels = append(headOfChannels, v)
+				break
+			}
 		}
-	}
-
-	bundlesPerChannels := BuildMapBundlesPerChannels(bundlesOfPackage)
-
-	// If for the package has no bundle set in the channels
-	// table as head of the channel then, we need to check
-	// the scenarios
-	if qtdHeads == 0 || qtdHeads != len(bundlesPerChannels) {
-		headOfChannels = GetLatestBundlesVersions(bundlesPerChannels)
 	}
 	return headOfChannels
 }
@@ -0,0 +1,294 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package custom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+const missingDependenciesSheet = "Missing Dependencies"
+const sharedAPIsSheet = "APIs Owned by Many Packages"
+const dependencyGraphSheet = "Dependency Graph"
+
+// PackageDependencies defines the dependencies of a package according to the head of its channels
+type PackageDependencies struct {
+	PackageName      string   `json:"packageName"`
+	HeadBundles      []string `json:"headBundles"`
+	OwnedAPIs        []string `json:"ownedAPIs,omitempty"`
+	RequiredAPIs     []string `json:"requiredAPIs,omitempty"`
+	RequiredPackages []string `json:"requiredPackages,omitempty"`
+	// DependsOn are the packages which provide the APIs and packages required
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+	// Missing are the APIs and packages required which are not provided by any head bundle of the catalog
+	Missing []string `json:"missing,omitempty"`
+	// Issues are the invalid versions of the head bundles and the invalid version ranges of the packages required
+	Issues []string `json:"issues,omitempty"`
+}
+
+// Dependency defines an edge of the dependency graph between the packages
+type Dependency struct {
+	PackageName string `json:"packageName"`
+	// Via is the API or package required which is provided by the package
+	Via string `json:"via"`
+}
+
+// SharedAPI defines an API owned by more than one package
+type SharedAPI struct {
+	API      string   `json:"api"`
+	Packages []string `json:"packages"`
+}
+
+// DependenciesReport defines the dependencies between the packages of the catalog
+type DependenciesReport struct {
+	Metadata   pkg.ReportMetadata    `json:"metadata"`
+	Packages   []PackageDependencies `json:"packages"`
+	SharedAPIs []SharedAPI           `json:"sharedAPIs,omitempty"`
+	outputPath string
+}
+
+// NewDependenciesReport checks that the APIs and packages required by the head bundles of the packages are
+// provided by the head bundles of the catalog, and finds the APIs owned by more than one package
+func NewDependenciesReport(bundlesReport bundles.Report, outputPath string) *DependenciesReport {
+	report := DependenciesReport{outputPath: outputPath}
+	report.Metadata = pkg.NewReportMetadata(report.Kind(), bundlesReport.Flags.IndexImage,
+		bundlesReport.IndexImageInspect, nil)
+
+	mapPackagesWithBundles := MapBundlesPerPackage(bundlesReport)
+	names := make([]string, 0, len(mapPackagesWithBundles))
+	for name := range mapPackagesWithBundles {
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// owners are the packages which own each API, versions are the valid versions of the head bundles and
+	// hasHeads are the packages which have head bundles regardless of their versions
+	owners := map[string][]string{}
+	versions := map[string][]semver.Version{}
+	hasHeads := map[string]bool{}
+	for _, name := range names {
+		deps := PackageDependencies{PackageName: name}
+		for _, head := range GetHeadOfChannels(mapPackagesWithBundles[name]) {
+			hasHeads[name] = true
+			deps.HeadBundles = append(deps.HeadBundles, head.BundleName)
+			deps.OwnedAPIs = append(deps.OwnedAPIs, head.OwnedAPIs...)
+			deps.RequiredAPIs = append(deps.RequiredAPIs, head.RequiredAPIs...)
+			deps.RequiredPackages = append(deps.RequiredPackages, head.RequiredPackages...)
+			version, err := bundles.ParseBundleVersion(head.BundleVersion)
+			if err != nil {
+				deps.Issues = append(deps.Issues, fmt.Sprintf("the head bundle %s has an invalid version %s : %s",
+					head.BundleName, head.BundleVersion, err))
+				continue
+			}
+			versions[name] = append(versions[name], version)
+		}
+		deps.OwnedAPIs = pkg.GetUniqueValues(deps.OwnedAPIs)
+		deps.RequiredAPIs = pkg.GetUniqueValues(deps.RequiredAPIs)
+		deps.RequiredPackages = pkg.GetUniqueValues(deps.RequiredPackages)
+		sort.Strings(deps.OwnedAPIs)
+		sort.Strings(deps.RequiredAPIs)
+		sort.Strings(deps.RequiredPackages)
+		for _, api := range deps.OwnedAPIs {
+			owners[api] = append(owners[api], name)
+		}
+		report.Packages = append(report.Packages, deps)
+	}
+
+	for i, deps := range report.Packages {
+		for _, api := range deps.RequiredAPIs {
+			if len(owners[api]) == 0 {
+				report.Packages[i].Missing = append(report.Packages[i].Missing, api)
+				continue
+			}
+			for _, owner := range owners[api] {
+				if owner != deps.PackageName {
+					report.Packages[i].DependsOn = append(report.Packages[i].DependsOn,
+						Dependency{PackageName: owner, Via: api})
+				}
+			}
+		}
+		for _, required := range deps.RequiredPackages {
+			name, versionRange := bundles.SplitRequiredPackage(required)
+			found, err := hasVersionInRange(hasHeads[name], versions[name], versionRange)
+			if err != nil {
+				report.Packages[i].Issues = append(report.Packages[i].Issues,
+					fmt.Sprintf("the version range %s required for the package %s is invalid : %s",
+						versionRange, name, err))
+				continue
+			}
+			if !found {
+				report.Packages[i].Missing = append(report.Packages[i].Missing, required)
+				continue
+			}
+			report.Packages[i].DependsOn = append(report.Packages[i].DependsOn,
+				Dependency{PackageName: name, Via: required})
+		}
+	}
+
+	apis := make([]string, 0, len(owners))
+	for api := range owners {
+		apis = append(apis, api)
+	}
+	sort.Strings(apis)
+	for _, api := range apis {
+		if len(owners[api]) > 1 {
+			report.SharedAPIs = append(report.SharedAPIs, SharedAPI{API: api, Packages: owners[api]})
+		}
+	}
+	return &report
+}
+
+// hasVersionInRange returns true when any version is in the range. The empty range is satisfied by any head
+// of the package, even when its version is invalid. An error is returned when the range is invalid
+func hasVersionInRange(hasHeads bool, versions []semver.Version, versionRange string) (bool, error) {
+	if len(versionRange) == 0 {
+		return hasHeads, nil
+	}
+	r, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range versions {
+		if r(v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Workbook returns the tabular representation of the report used by the writers
+func (r *DependenciesReport) Workbook() *pkg.Workbook {
+	p := r.Packages
+	rows := len(p)
+	dt := time.Now().Format("2006-01-02")
+	return &pkg.Workbook{
+		Title: fmt.Sprintf("Audit Dependencies Report (Generated at %s)", dt),
+		Metadata: []pkg.SummaryField{
+			{Name: "Image used", Value: r.Metadata.Image},
+			{Name: "Image Index Digest", Value: r.Metadata.CatalogDigest},
+			{Name: "Generated at", Value: r.Metadata.GeneratedAt},
+			{Name: "Audit Tool Version", Value: r.Metadata.Tool.Version},
+		},
+		Counts: []pkg.SummaryField{
+			{Name: "Packages", Value: rows},
+			{Name: "Packages with dependencies", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return len(p[i].RequiredAPIs) > 0 || len(p[i].RequiredPackages) > 0
+			})},
+			{Name: "Packages with missing dependencies", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return len(p[i].Missing) > 0
+			})},
+			{Name: "Packages with invalid versions or ranges", Value: pkg.CountRowsWith(rows, func(i int) bool {
+				return len(p[i].Issues) > 0
+			})},
+			{Name: "APIs owned by many packages", Value: len(r.SharedAPIs)},
+		},
+		TableName: "Packages",
+		Columns: []pkg.TableColumn{
+			{Name: "packageName", Header: "Package Name", Value: func(i int) interface{} { return p[i].PackageName }},
+			{Name: "headBundles", Header: "Head Bundles",
+				Value: func(i int) interface{} { return strings.Join(p[i].HeadBundles, "\n") }},
+			{Name: "ownedAPIs", Header: "Owned APIs",
+				Value: func(i int) interface{} { return strings.Join(p[i].OwnedAPIs, "\n") }},
+			{Name: "requiredAPIs", Header: "Required APIs",
+				Value: func(i int) interface{} { return strings.Join(p[i].RequiredAPIs, "\n") }},
+			{Name: "requiredPackages", Header: "Required Packages",
+				Value: func(i int) interface{} { return strings.Join(p[i].RequiredPackages, "\n") }},
+			{Name: "dependsOn", Header: "Depends On",
+				Value:  func(i int) interface{} { return strings.Join(dependsOnPackages(p[i]), ", ") },
+				LinkTo: dependencyGraphSheet},
+			{Name: "missing", Header: "Missing Dependencies",
+				Value: func(i int) interface{} { return len(p[i].Missing) },
+				Highlight: func(i int) pkg.Highlight {
+					if len(p[i].Missing) > 0 {
+						return pkg.HighlightRed
+					}
+					return pkg.NoHighlight
+				},
+				LinkTo: missingDependenciesSheet},
+			{Name: "issues", Header: "Issues",
+				Value: func(i int) interface{} { return strings.Join(p[i].Issues, "\n") },
+				Highlight: func(i int) pkg.Highlight {
+					if len(p[i].Issues) > 0 {
+						return pkg.HighlightOrange
+					}
+					return pkg.NoHighlight
+				}},
+		},
+		Rows:    rows,
+		Details: r.workbookDetails(),
+	}
+}
+
+func (r *DependenciesReport) workbookDetails() []pkg.DetailSheet {
+	missing := pkg.DetailSheet{Name: missingDependenciesSheet,
+		Headers: []string{"Package Name", "Dependency"}}
+	graph := pkg.DetailSheet{Name: dependencyGraphSheet,
+		Headers: []string{"Package Name", "Depends On", "Via"}}
+	shared := pkg.DetailSheet{Name: sharedAPIsSheet,
+		Headers: []string{"API", "Packages"}}
+
+	index := map[string]int{}
+	for i, v := range r.Packages {
+		index[v.PackageName] = i
+		for _, m := range v.Missing {
+			missing.Rows = append(missing.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, m}, Highlight: pkg.HighlightRed})
+		}
+		for _, d := range v.DependsOn {
+			graph.Rows = append(graph.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, d.PackageName, d.Via}})
+		}
+	}
+	for _, v := range r.SharedAPIs {
+		shared.Rows = append(shared.Rows, pkg.DetailRow{Owner: index[v.Packages[0]],
+			Values: []interface{}{v.API, strings.Join(v.Packages, ", ")}, Highlight: pkg.HighlightOrange})
+	}
+	return []pkg.DetailSheet{missing, graph, shared}
+}
+
+// dependsOnPackages returns the names of the packages which the package depends on
+func dependsOnPackages(deps PackageDependencies) []string {
+	var names []string
+	for _, v := range deps.DependsOn {
+		names = append(names, v.PackageName)
+	}
+	names = pkg.GetUniqueValues(names)
+	sort.Strings(names)
+	return names
+}
+
+// Kind returns the type of the report
+func (r *DependenciesReport) Kind() string {
+	return "dependencies"
+}
+
+// ImageName returns the index image audited
+func (r *DependenciesReport) ImageName() string {
+	return r.Metadata.Image
+}
+
+// OutputPath returns the directory where the report should be written
+func (r *DependenciesReport) OutputPath() string {
+	return r.outputPath
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package custom
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+func TestNewDependenciesReport(t *testing.T) {
+	const etcdAPI = "etcd.database.coreos.com/v1beta2/EtcdCluster"
+	report := bundles.Report{Flags: bundles.BindFlags{IndexImage: "quay.io/operatorhubio/catalog:latest"},
+		Columns: []bundles.Column{
+			{PackageName: "etcd", BundleName: "etcd.v0.9.4", BundleVersion: "0.9.4", Channels: []string{"alpha"},
+				IsHeadOfChannel: true, OwnedAPIs: []string{etcdAPI}},
+			{PackageName: "etcd-fork", BundleName: "etcd-fork.v1.0.0", BundleVersion: "1.0.0",
+				Channels: []string{"stable"}, IsHeadOfChannel: true, OwnedAPIs: []string{etcdAPI}},
+			{PackageName: "app", BundleName: "app.v1.0.0", BundleVersion: "1.0.0", Channels: []string{"stable"},
+				IsHeadOfChannel: true, RequiredAPIs: []string{etcdAPI, "cache.example.com/v1/Memcached"},
+				RequiredPackages: []string{"etcd (>=0.9.0)", "etcd-fork (>=2.0.0)", "legacy", "etcd (>>1)"}},
+			{PackageName: "legacy", BundleName: "legacy.latest", BundleVersion: "latest", Channels: []string{"stable"},
+				IsHeadOfChannel: true},
+		}}
+
+	_, versionErr := bundles.ParseBundleVersion("latest")
+	_, rangeErr := semver.ParseRange(">>1")
+
+	got := NewDependenciesReport(report, "")
+	want := []PackageDependencies{
+		{PackageName: "app", HeadBundles: []string{"app.v1.0.0"},
+			RequiredAPIs:     []string{"cache.example.com/v1/Memcached", etcdAPI},
+			RequiredPackages: []string{"etcd (>=0.9.0)", "etcd (>>1)", "etcd-fork (>=2.0.0)", "legacy"},
+			DependsOn: []Dependency{
+				{PackageName: "etcd", Via: etcdAPI},
+				{PackageName: "etcd-fork", Via: etcdAPI},
+				{PackageName: "etcd", Via: "etcd (>=0.9.0)"},
+				{PackageName: "legacy", Via: "legacy"},
+			},
+			Missing: []string{"cache.example.com/v1/Memcached", "etcd-fork (>=2.0.0)"},
+			Issues:  []string{"the version range >>1 required for the package etcd is invalid : " + rangeErr.Error()}},
+		{PackageName: "etcd", HeadBundles: []string{"etcd.v0.9.4"}, OwnedAPIs: []string{etcdAPI}},
+		{PackageName: "etcd-fork", HeadBundles: []string{"etcd-fork.v1.0.0"}, OwnedAPIs: []string{etcdAPI}},
+		{PackageName: "legacy", HeadBundles: []string{"legacy.latest"},
+			Issues: []string{"the head bundle legacy.latest has an invalid version latest : " + versionErr.Error()}},
+	}
+	if !reflect.DeepEqual(got.Packages, want) {
+		t.Errorf("NewDependenciesReport() packages = %+v, want %+v", got.Packages, want)
+	}
+	if got.Metadata.Kind != "dependencies" || got.Metadata.Catalog != "quay.io/operatorhubio/catalog" {
+		t.Errorf("NewDependenciesReport() metadata = %+v", got.Metadata)
+	}
+	wantShared := []SharedAPI{{API: etcdAPI, Packages: []string{"etcd", "etcd-fork"}}}
+	if !reflect.DeepEqual(got.SharedAPIs, wantShared) {
+		t.Errorf("NewDependenciesReport() shared APIs = %+v, want %+v", got.SharedAPIs, wantShared)
+	}
+}
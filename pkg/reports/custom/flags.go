@@ -16,8 +16,9 @@ package custom
 
 // BindFlags define the Flags used to generate the bundle report
 type BindFlags struct {
-	File       string `json:"file"`
-	OutputPath string `json:"outputPath"`
+	File         string `json:"file"`
+	OutputPath   string `json:"outputPath"`
+	OutputFormat string `json:"outputFormat,omitempty"`
 }
 
 var Flags = BindFlags{}
@@ -15,6 +15,7 @@
 package custom
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/operator-framework/audit/pkg"
@@ -74,39 +75,6 @@ const GREEN = "green"
 const ORANGE = "orange"
 const BLACK = "black"
 
-//the following data came from: https://access.redhat.com/articles/4740011
-var pkgsThatSupportsDisconnectedMode = []string{
-	"3scale apicast-operator",
-	"3scale-operator",
-	"amq-streams",
-	"businessautomation-operator",
-	"cam-operator",
-	"cluster-logging",
-	"codeready-toolchain-operator",
-	"codeready-workspaces",
-	"compliance-operator",
-	"datagrid",
-	"elasticsearch-operator",
-	"file-integrity-operator",
-	"fuse-online",
-	"jaeger-product",
-	"jenkins-operator",
-	"kiali-ossm",
-	"kubevirt-hyperconverge",
-	"local-storage-operator",
-	"metering-ocp",
-	"mtc-operator",
-	"nfd",
-	"ocs-operator",
-	"openshift-gitops-operator",
-	"openshift", // seems that all that we have now should support //todo: identify all names
-	"ptp-operator",
-	"quay", // todo: check the specific names either
-	"serverless-operator",
-	"servicemeshoperator",
-	"sriov-network-operator",
-}
-
 type PackageGrade struct {
 	PackageName                 string
 	DeprecateAPI                string
@@ -127,6 +95,7 @@ type PackageGrade struct {
 	Grade                       string
 	ChannelNamesNotComply       []string
 	BundlesWithoutDisconnect    []string
+	InfrastructureFeatures      []string
 	HeadOfChannels              []bundles.Column
 }
 
@@ -176,6 +145,10 @@ func NewPkgGrade(pkgName string, bundlesOfPkg []bundles.Column,
 	pkgGrade.ValidatorsColor = BLACK
 
 	pkgGrade.HeadOfChannels = GetHeadOfChannels(bundlesOfPkg)
+	for _, v := range pkgGrade.HeadOfChannels {
+		pkgGrade.InfrastructureFeatures = append(pkgGrade.InfrastructureFeatures, v.InfrastructureFeatures.Names()...)
+	}
+	pkgGrade.InfrastructureFeatures = pkg.GetUniqueValues(pkgGrade.InfrastructureFeatures)
 
 	pkgGrade.checkDeprecatedAPIScore(notComplying, partialComplying, complying)
 	pkgGrade.checkDisconnectAnnotationScore()
@@ -336,17 +309,22 @@ func (p *PackageGrade) checkValidatorsScore() {
 	}
 }
 
+// checkDisconnectAnnotationScore checks if the head of the channels are ready to be installed in disconnected
+// environments. It is REQUIRED when a bundle declares the Disconnected feature but it is not ready. The issues
+// found with the infrastructure annotations are reported too, since the feature cannot be trusted with them.
 func (p *PackageGrade) checkDisconnectAnnotationScore() {
-	found := false
-	for _, v := range pkgsThatSupportsDisconnectedMode {
-		if strings.Contains(p.PackageName, v) {
-			found = true
-			break
-		}
-	}
+	declared := false
 	for _, b := range p.HeadOfChannels {
-		if b.Infrastructure != "[\"Disconnected\"]" {
-			p.BundlesWithoutDisconnect = append(p.BundlesWithoutDisconnect, b.BundleName)
+		if b.InfrastructureFeatures.Disconnected {
+			declared = true
+		} else if len(b.DisconnectedReasons) == 0 {
+			// the reports generated before the readiness check have no reasons
+			p.BundlesWithoutDisconnect = append(p.BundlesWithoutDisconnect,
+				fmt.Sprintf("%s: Disconnected is not declared", b.BundleName))
+		}
+		for _, reason := range append(append([]string{}, b.InfrastructureIssues...), b.DisconnectedReasons...) {
+			p.BundlesWithoutDisconnect = append(p.BundlesWithoutDisconnect,
+				fmt.Sprintf("%s: %s", b.BundleName, reason))
 		}
 	}
 
@@ -355,7 +333,7 @@ func (p *PackageGrade) checkDisconnectAnnotationScore() {
 		p.DisconnectedAnnotationColor = GREEN
 		p.Score += scoreMap[USED]
 	} else {
-		if found {
+		if declared {
 			p.DisconnectedAnnotation = "REQUIRED"
 			p.DisconnectedAnnotationColor = RED
 		} else {
@@ -23,37 +23,43 @@ import (
 )
 
 type Column struct {
-	PackageName                  string   `json:"packageName"`
-	KindsDeprecateAPIs           []string `json:"kindsDeprecateAPIs,omitempty"`
-	HasWebhooks                  bool     `json:"hasWebhooks,omitempty"`
-	MultipleArchitectures        []string `json:"multipleArchitectures,omitempty"`
-	HasValidatorErrors           bool     `json:"hasValidatorErrors,omitempty"`
-	HasValidatorWarnings         bool     `json:"hasValidatorWarnings"`
-	HasScorecardFailingTests     bool     `json:"hasScorecardFailingTests"`
-	HasScorecardSuggestions      bool     `json:"hasScorecardSuggestions"`
-	ValidatorErrors              []string `json:"validatorErrors,omitempty"`
-	ValidatorWarnings            []string `json:"validatorWarnings,omitempty"`
-	ScorecardErrors              []string `json:"scorecardErrors,omitempty"`
-	ScorecardSuggestions         []string `json:"scorecardSuggestions,omitempty"`
-	ScorecardFailingTests        []string `json:"scorecardFailingTests,omitempty"`
-	HasInvalidSkipRange          bool     `json:"hasInvalidSkipRange,omitempty"`
-	HasInvalidVersioning         bool     `json:"hasInvalidVersioning,omitempty"`
-	IsMultiChannel               bool     `json:"isMultiChannel,omitempty"`
-	HasSupportForAllNamespaces   bool     `json:"hasSupportForAllNamespaces,omitempty"`
-	HasSupportForMultiNamespaces bool     `json:"hasSupportForMultiNamespaces,omitempty"`
-	HasSupportForSingleNamespace bool     `json:"hasSupportForSingleNamespaces,omitempty"`
-	HasSupportForOwnNamespaces   bool     `json:"hasSupportForOwnNamespaces,omitempty"`
-	HasInfraAnnotation           bool     `json:"hasInfraAnnotation,omitempty"`
-	HasPossiblePerformIssues     bool     `json:"hasPossiblePerformIssues,omitempty"`
-	HasCustomScorecardTests      bool     `json:"hasCustomScorecardTests,omitempty"`
-	AuditErrors                  []string `json:"errors,omitempty"`
+	PackageName                  string              `json:"packageName"`
+	KindsDeprecateAPIs           []string            `json:"kindsDeprecateAPIs,omitempty"`
+	DeprecateAPIsManifests       map[string][]string `json:"deprecateAPIsManifests,omitempty"`
+	HasWebhooks                  bool                `json:"hasWebhooks,omitempty"`
+	WebhookTypes                 []string            `json:"webhookTypes,omitempty"`
+	WebhookIssues                []string            `json:"webhookIssues,omitempty"`
+	InfrastructureIssues         []string            `json:"infrastructureIssues,omitempty"`
+	SecurityIssues               []string            `json:"securityIssues,omitempty"`
+	MultipleArchitectures        []string            `json:"multipleArchitectures,omitempty"`
+	HasValidatorErrors           bool                `json:"hasValidatorErrors,omitempty"`
+	HasValidatorWarnings         bool                `json:"hasValidatorWarnings"`
+	HasScorecardFailingTests     bool                `json:"hasScorecardFailingTests"`
+	HasScorecardSuggestions      bool                `json:"hasScorecardSuggestions"`
+	ValidatorErrors              []string            `json:"validatorErrors,omitempty"`
+	ValidatorWarnings            []string            `json:"validatorWarnings,omitempty"`
+	ScorecardErrors              []string            `json:"scorecardErrors,omitempty"`
+	ScorecardSuggestions         []string            `json:"scorecardSuggestions,omitempty"`
+	ScorecardFailingTests        []string            `json:"scorecardFailingTests,omitempty"`
+	HasInvalidSkipRange          bool                `json:"hasInvalidSkipRange,omitempty"`
+	HasInvalidVersioning         bool                `json:"hasInvalidVersioning,omitempty"`
+	IsMultiChannel               bool                `json:"isMultiChannel,omitempty"`
+	HasSupportForAllNamespaces   bool                `json:"hasSupportForAllNamespaces,omitempty"`
+	HasSupportForMultiNamespaces bool                `json:"hasSupportForMultiNamespaces,omitempty"`
+	HasSupportForSingleNamespace bool                `json:"hasSupportForSingleNamespaces,omitempty"`
+	HasSupportForOwnNamespaces   bool                `json:"hasSupportForOwnNamespaces,omitempty"`
+	HasInfraAnnotation           bool                `json:"hasInfraAnnotation,omitempty"`
+	InfrastructureFeatures       []string            `json:"infrastructureFeatures,omitempty"`
+	HasPossiblePerformIssues     bool                `json:"hasPossiblePerformIssues,omitempty"`
+	HasCustomScorecardTests      bool                `json:"hasCustomScorecardTests,omitempty"`
+	AuditErrors                  []string            `json:"errors,omitempty"`
 }
 
 func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 	col := Column{}
 	col.PackageName = auditPkg.PackageName
 
-	allBundles := getAllBundles(data.Flags.Label, auditPkg)
+	allBundles := getAllBundles(auditPkg)
 
 	var auditErrors []string
 	var validatorErrors []string
@@ -63,6 +69,12 @@ func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 	var scorecardFailingTests []string
 	var muiltArchSupport []string
 	var kindsFromRemovedAPI []string
+	manifestsFromRemovedAPI := map[string][]string{}
+	var infrastructureFeatures []string
+	var webhookTypes []string
+	var webhookIssues []string
+	var infrastructureIssues []string
+	var securityIssues []string
 
 	foundWebhooks := false
 	foundScorecardSuggestions := false
@@ -90,6 +102,22 @@ func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 		scorecardFailingTests = append(scorecardFailingTests, v.ScorecardFailingTests...)
 		muiltArchSupport = append(muiltArchSupport, v.MultipleArchitectures...)
 		kindsFromRemovedAPI = append(kindsFromRemovedAPI, v.KindsDeprecateAPIs...)
+		for kind, manifests := range v.DeprecateAPIsManifests {
+			manifestsFromRemovedAPI[kind] = pkg.GetUniqueValues(append(manifestsFromRemovedAPI[kind], manifests...))
+		}
+		infrastructureFeatures = append(infrastructureFeatures, v.InfrastructureFeatures.Names()...)
+		for _, issue := range v.InfrastructureIssues {
+			infrastructureIssues = append(infrastructureIssues, fmt.Sprintf("%s: %s", v.BundleName, issue))
+		}
+		for _, w := range v.Webhooks {
+			webhookTypes = append(webhookTypes, w.Type)
+			for _, issue := range w.Issues {
+				webhookIssues = append(webhookIssues, fmt.Sprintf("%s (%s): %s", v.BundleName, w.Name, issue))
+			}
+		}
+		for _, s := range v.SecurityIssues {
+			securityIssues = append(securityIssues, s.Issue)
+		}
 		if len(v.KindsDeprecateAPIs) > 0 && v.KindsDeprecateAPIs[0] == pkg.Unknown {
 			qtUnknown++
 		}
@@ -130,7 +158,7 @@ func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 			foundSupportingSingleNamespaces = v.IsSupportingSingleNamespace
 		}
 		if !foundInfraSupport {
-			foundInfraSupport = len(v.Infrastructure) > 0
+			foundInfraSupport = len(v.Infrastructure) > 0 || v.HasFeaturesAnnotations
 		}
 		if !foundPossiblePerformIssues {
 			foundPossiblePerformIssues = v.HasPossiblePerformIssues
@@ -160,8 +188,16 @@ func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 	col.HasSupportForOwnNamespaces = foundSupportingOwnNamespaces
 	col.HasSupportForSingleNamespace = foundSupportingSingleNamespaces
 	col.HasInfraAnnotation = foundInfraSupport
+	col.InfrastructureFeatures = pkg.GetUniqueValues(infrastructureFeatures)
+	col.WebhookTypes = pkg.GetUniqueValues(webhookTypes)
+	col.WebhookIssues = webhookIssues
+	col.InfrastructureIssues = infrastructureIssues
+	col.SecurityIssues = pkg.GetUniqueValues(securityIssues)
 	col.HasPossiblePerformIssues = foundPossiblePerformIssues
 	col.KindsDeprecateAPIs = pkg.GetUniqueValues(kindsFromRemovedAPI)
+	if len(manifestsFromRemovedAPI) > 0 {
+		col.DeprecateAPIsManifests = manifestsFromRemovedAPI
+	}
 	col.HasCustomScorecardTests = foundCustomScorecards
 
 	// If was not possible get any bundle then needs to be Unknown
@@ -179,13 +215,9 @@ func NewColumn(data *Data, auditPkg models.AuditPackage) *Column {
 
 }
 
-func getAllBundles(label string, auditPkg models.AuditPackage) []bundles.Column {
+func getAllBundles(auditPkg models.AuditPackage) []bundles.Column {
 	var allBundles []bundles.Column
 	for _, v := range auditPkg.AuditBundle {
-		// do not add bundle which has not the label
-		if len(label) > 0 && !v.FoundLabel {
-			continue
-		}
 		bundle := bundles.NewColumn(v)
 		allBundles = append(allBundles, *bundle)
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/operator-framework/audit/pkg"
 	"github.com/operator-framework/audit/pkg/models"
 	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/writers"
 )
 
 type Data struct {
@@ -36,6 +37,10 @@ type Data struct {
 func (d *Data) PrepareReport() Report {
 	var allColumns []Column
 	for _, auditPkg := range d.AuditPackage {
+		// do not add the packages which have no bundles matching the label selectors
+		if d.Flags.HasLabelFilter() && len(auditPkg.AuditBundle) == 0 {
+			continue
+		}
 		col := NewColumn(d, auditPkg)
 		allColumns = append(allColumns, *col)
 	}
@@ -51,6 +56,8 @@ func (d *Data) PrepareReport() Report {
 
 	dt := time.Now().Format("2006-01-02")
 	finalReport.GenerateAt = dt
+	finalReport.Metadata = pkg.NewReportMetadata(finalReport.Kind(), d.Flags.IndexImage, d.IndexImageInspect,
+		pkg.EnabledChecks(d.Flags.DisableScorecard, d.Flags.DisableValidators))
 
 	if len(allColumns) == 0 {
 		log.Fatal("No data was found for the criteria informed. " +
@@ -62,26 +69,7 @@ func (d *Data) PrepareReport() Report {
 
 func (d *Data) OutputReport() error {
 	report := d.PrepareReport()
-	switch d.Flags.OutputFormat {
-	case pkg.Xls:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-	case pkg.JSON:
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	case pkg.All:
-		if err := report.writeXls(); err != nil {
-			return err
-		}
-		if err := report.writeJSON(); err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("invalid output format : %s", d.Flags.OutputFormat)
-	}
-	return nil
+	return writers.Output(&report, d.Flags.OutputFormat)
 }
 
 func (d *Data) BuildPackagesQuery() (string, error) {
@@ -15,14 +15,20 @@
 package packages
 
 type BindFlags struct {
-	IndexImage        string `json:"index-image"`
-	Limit             int32  `json:"limit"`
-	Filter            string `json:"filter"`
-	Label             string `json:"label"`
-	LabelValue        string `json:"labelValue"`
-	OutputPath        string `json:"outputPath"`
-	OutputFormat      string `json:"outputFormat"`
-	DisableScorecard  bool   `json:"disableScorecard"`
-	DisableValidators bool   `json:"disableValidators"`
-	ServerMode        bool   `json:"serverMode"`
+	IndexImage        string   `json:"index-image"`
+	Limit             int32    `json:"limit"`
+	Filter            string   `json:"filter"`
+	Label             string   `json:"label"`
+	LabelValue        string   `json:"labelValue"`
+	LabelSelectors    []string `json:"labelSelectors,omitempty"`
+	OutputPath        string   `json:"outputPath"`
+	OutputFormat      string   `json:"outputFormat"`
+	DisableScorecard  bool     `json:"disableScorecard"`
+	DisableValidators bool     `json:"disableValidators"`
+	ServerMode        bool     `json:"serverMode"`
+}
+
+// HasLabelFilter returns true when the bundles should be filtered by the labels of their images
+func (b BindFlags) HasLabelFilter() bool {
+	return len(b.Label) > 0 || len(b.LabelSelectors) > 0
 }
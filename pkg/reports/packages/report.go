@@ -15,16 +15,12 @@
 package packages
 
 import (
-	"encoding/json"
 	"fmt"
-	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
-	log "github.com/sirupsen/logrus"
-
 	"github.com/operator-framework/audit/pkg"
-
-	"github.com/360EntSecGroup-Skylar/excelize/v2"
 )
 
 type Report struct {
@@ -32,261 +28,317 @@ type Report struct {
 	Flags             BindFlags `json:"flags"`
 	IndexImageInspect pkg.DockerInspectManifest
 	GenerateAt        string
+	Metadata          pkg.ReportMetadata `json:"metadata"`
 }
 
-//todo: fix the complexity
-//nolint:gocyclo
-func (r *Report) writeXls() error {
-	const sheetName = "Sheet1"
-	f := excelize.NewFile()
-
-	styleOrange, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#ec8f1c",
-		},
-	})
+const validatorFindingsSheet = "Validator Findings"
+const scorecardTestsSheet = "Scorecard Tests"
+const auditErrorsSheet = "Audit Errors"
+const deprecatedAPIsSheet = "Deprecated API Manifests"
 
-	styleRed, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#EC1C1C",
-		},
-	})
+// Migrate checks that the schema version of the report is supported and fills the metadata of the
+// reports generated before it was added
+func (r *Report) Migrate() error {
+	return r.Metadata.Migrate(r.Kind(), r.Flags.IndexImage, r.GenerateAt)
+}
 
-	styleGreen, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Color: "#3FA91E",
-		},
-	})
-	columns := map[string]string{
-		"A": "Package Name",
-		"B": "Kinds \n (Suggestion API(s) usage)",
-		"C": "Is using Webhooks",
-		"D": "Multiple Architectures used",
-		"E": "Has Scorecard Suggestions",
-		"F": "Has Scorecard Falling Tests",
-		"G": "Has Validator Errors",
-		"H": "Has Validator Warnings",
-		"I": "Has Invalid Versioning",
-		"J": "Has Invalid SkipRange",
-		"K": "Is multi-channel",
-		"L": "Has Support for All Namespaces",
-		"M": "Has Support for Single Namespaces",
-		"N": "Has Support for Own Namespaces",
-		"O": "Has Support for Multi Namespaces",
-		"P": "Has Infrastructure Support",
-		"Q": "Has possible performance issues",
-		"R": "Has custom Scorecards",
-		"S": "Issues (To process this report)",
-	}
+// Schema returns the JSON Schema of the report
+func Schema() *pkg.JSONSchema {
+	s := pkg.NewJSONSchema("Audit Packages Report", Report{})
+	s.Properties["metadata"].Properties["schemaVersion"].Enum = []string{pkg.SchemaVersion}
+	return s
+}
 
-	// Header
+// Workbook returns the tabular representation of the report used by the writers
+func (r *Report) Workbook() *pkg.Workbook {
 	dt := time.Now().Format("2006-01-02")
-	_ = f.SetCellValue(sheetName, "A1",
-		fmt.Sprintf("Audit Packages Report (Generated at %s). IMPORTANT: This report only checks the head "+
-			"operators of the channels. Use the bundles report to check all bundles", dt))
-	_ = f.SetCellValue(sheetName, "A2", "Image used")
-	_ = f.SetCellValue(sheetName, "B2", r.Flags.IndexImage)
-	_ = f.SetCellValue(sheetName, "A3", "Image Index Create Date:")
-	_ = f.SetCellValue(sheetName, "B3", r.IndexImageInspect.Created)
-	_ = f.SetCellValue(sheetName, "A4", "Image Index ID:")
-	_ = f.SetCellValue(sheetName, "B4", r.IndexImageInspect.ID)
-
-	for k, v := range columns {
-		_ = f.SetCellValue(sheetName, fmt.Sprintf("%s5", k), v)
+	return &pkg.Workbook{
+		Title: fmt.Sprintf("Audit Packages Report (Generated at %s). IMPORTANT: This report only checks the head "+
+			"operators of the channels. Use the bundles report to check all bundles", dt),
+		Metadata:  r.workbookMetadata(),
+		Counts:    r.workbookCounts(),
+		Charts:    r.workbookCharts(),
+		TableName: "Packages",
+		Columns:   r.workbookColumns(),
+		Rows:      len(r.Columns),
+		Details:   r.workbookDetails(),
 	}
+}
 
-	for k, v := range r.Columns {
-		line := k + 6
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("A%d", line), v.PackageName); err != nil {
-			log.Errorf("to add packageName cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("B%d", line), v.KindsDeprecateAPIs); err != nil {
-			log.Errorf("to add KindsDeprecateAPIs cell value: %s", err)
-		}
-		if len(v.KindsDeprecateAPIs) > 0 {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("B%d", line),
-				fmt.Sprintf("B%d", line), styleOrange)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("C%d", line),
-			pkg.GetYesOrNo(v.HasWebhooks)); err != nil {
-			log.Errorf("to add HasWebhooks cell value: %s", err)
-		}
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("D%d", line),
-			pkg.GetFormatArrayWithBreakLine(v.MultipleArchitectures)); err != nil {
-			log.Errorf("to add MultipleArchitectures cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("E%d", line),
-			pkg.GetYesOrNo(v.HasScorecardSuggestions)); err != nil {
-			log.Errorf("to add HasScorecardSuggestions cell value: %s", err)
-		}
-
-		if v.HasScorecardSuggestions {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("E%d", line),
-				fmt.Sprintf("E%d", line), styleOrange)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("F%d", line),
-			pkg.GetYesOrNo(v.HasScorecardFailingTests)); err != nil {
-			log.Errorf("to add HasScorecardFailingTests cell value: %s", err)
-		}
-
-		if len(v.ScorecardFailingTests) > 0 {
-			if err := f.AddComment(sheetName, fmt.Sprintf("F%d", line),
-				fmt.Sprintf(`{"author":"Audit: ","text":"%s"}`, v.ScorecardFailingTests)); err != nil {
-				log.Errorf("to add comment for ScorecardFailingTests: %s", err)
-			}
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("F%d", line),
-				fmt.Sprintf("F%d", line), styleRed)
-		} else {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("F%d", line),
-				fmt.Sprintf("F%d", line), styleGreen)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("G%d", line),
-			pkg.GetYesOrNo(v.HasValidatorErrors)); err != nil {
-			log.Errorf("to add HasValidatorErrors cell value: %s", err)
-		}
-
-		if v.HasValidatorErrors {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("G%d", line),
-				fmt.Sprintf("G%d", line), styleRed)
-		} else {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("G%d", line),
-				fmt.Sprintf("G%d", line), styleGreen)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("H%d", line),
-			pkg.GetYesOrNo(v.HasValidatorWarnings)); err != nil {
-			log.Errorf("to add HasValidatorWarnings cell value: %s", err)
-		}
-
-		if v.HasValidatorWarnings {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("H%d", line),
-				fmt.Sprintf("H%d", line), styleOrange)
-		} else {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("H%d", line),
-				fmt.Sprintf("H%d", line), styleGreen)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("I%d", line),
-			pkg.GetYesOrNo(v.HasInvalidVersioning)); err != nil {
-			log.Errorf("to add HasInvalidVersioning cell value: %s", err)
-		}
-
-		if v.HasInvalidVersioning {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("I%d", line),
-				fmt.Sprintf("I%d", line), styleOrange)
-		} else {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("I%d", line),
-				fmt.Sprintf("I%d", line), styleGreen)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("J%d", line),
-			pkg.GetYesOrNo(v.HasInvalidSkipRange)); err != nil {
-			log.Errorf("to add HasInvalidSkipRange cell value: %s", err)
-		}
-		if v.HasInvalidSkipRange {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("J%d", line),
-				fmt.Sprintf("J%d", line), styleOrange)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("K%d", line),
-			pkg.GetYesOrNo(v.IsMultiChannel)); err != nil {
-			log.Errorf("to add IsMultiChannel cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("L%d", line),
-			pkg.GetYesOrNo(v.HasSupportForAllNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForAllNamespaces cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("M%d", line),
-			pkg.GetYesOrNo(v.HasSupportForSingleNamespace)); err != nil {
-			log.Errorf("to add HasSupportForMultiNamespaces cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("N%d", line),
-			pkg.GetYesOrNo(v.HasSupportForOwnNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForMultiNamespaces cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("O%d", line),
-			pkg.GetYesOrNo(v.HasSupportForMultiNamespaces)); err != nil {
-			log.Errorf("to add HasSupportForMultiNamespaces cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("P%d", line),
-			pkg.GetYesOrNo(v.HasInfraAnnotation)); err != nil {
-			log.Errorf("to add HasInfraAnnotation cell value: %s", err)
-		}
-
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("Q%d", line),
-			pkg.GetYesOrNo(v.HasPossiblePerformIssues)); err != nil {
-			log.Errorf("to add HasPossiblePerformIssues cell value: %s", err)
-		}
+func (r *Report) workbookMetadata() []pkg.SummaryField {
+	return []pkg.SummaryField{
+		{Name: "Image used", Value: r.Flags.IndexImage},
+		{Name: "Image Index Create Date", Value: r.IndexImageInspect.Created},
+		{Name: "Image Index ID", Value: r.IndexImageInspect.ID},
+		{Name: "Image Index Digest", Value: r.Metadata.CatalogDigest},
+		{Name: "Generated at", Value: r.Metadata.GeneratedAt},
+		{Name: "Audit Tool Version", Value: r.Metadata.Tool.Version},
+		{Name: "Filter", Value: r.Flags.Filter},
+		{Name: "Scorecard enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableScorecard)},
+		{Name: "Validators enabled", Value: pkg.GetYesOrNo(!r.Flags.DisableValidators)},
+	}
+}
 
-		if v.HasPossiblePerformIssues {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("Q%d", line),
-				fmt.Sprintf("Q%d", line), styleOrange)
-		}
+func (r *Report) workbookCounts() []pkg.SummaryField {
+	rows := len(r.Columns)
+	return []pkg.SummaryField{
+		{Name: "Packages", Value: rows},
+		{Name: "Packages with validator errors", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].HasValidatorErrors
+		})},
+		{Name: "Packages with validator warnings", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].HasValidatorWarnings
+		})},
+		{Name: "Packages with scorecard failing tests", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].HasScorecardFailingTests
+		})},
+		{Name: "Packages using removed API(s) on 1.22", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return usesRemovedAPIs(r.Columns[i])
+		})},
+		{Name: "Multi-channel packages", Value: pkg.CountRowsWith(rows, func(i int) bool {
+			return r.Columns[i].IsMultiChannel
+		})},
+	}
+}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("R%d", line),
-			pkg.GetYesOrNo(v.HasCustomScorecardTests)); err != nil {
-			log.Errorf("to add HasCustomScorecardTests cell value: %s", err)
-		}
+func (r *Report) workbookCharts() []pkg.SummaryChart {
+	rows := len(r.Columns)
+	usingRemovedAPIs := pkg.CountRowsWith(rows, func(i int) bool {
+		return usesRemovedAPIs(r.Columns[i])
+	})
+	return []pkg.SummaryChart{
+		{
+			Title: "Removed API(s) on 1.22",
+			Type:  "pie",
+			Series: []pkg.SummaryField{
+				{Name: "Using", Value: usingRemovedAPIs},
+				{Name: "Not using", Value: rows - usingRemovedAPIs},
+			},
+		},
+		{
+			Title: "Install modes supported",
+			Type:  "col",
+			Series: []pkg.SummaryField{
+				{Name: "AllNamespaces", Value: pkg.CountRowsWith(rows, func(i int) bool {
+					return r.Columns[i].HasSupportForAllNamespaces
+				})},
+				{Name: "SingleNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+					return r.Columns[i].HasSupportForSingleNamespace
+				})},
+				{Name: "OwnNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+					return r.Columns[i].HasSupportForOwnNamespaces
+				})},
+				{Name: "MultiNamespace", Value: pkg.CountRowsWith(rows, func(i int) bool {
+					return r.Columns[i].HasSupportForMultiNamespaces
+				})},
+			},
+		},
+	}
+}
 
-		if v.HasCustomScorecardTests {
-			_ = f.SetCellStyle(sheetName, fmt.Sprintf("R%d", line),
-				fmt.Sprintf("R%d", line), styleGreen)
-		}
+func usesRemovedAPIs(c Column) bool {
+	return len(c.KindsDeprecateAPIs) > 0 && c.KindsDeprecateAPIs[0] != pkg.Unknown
+}
 
-		if err := f.SetCellValue(sheetName, fmt.Sprintf("S%d", line), v.AuditErrors); err != nil {
-			log.Errorf("to add AuditErrors cell value: %s", err)
+func (r *Report) workbookColumns() []pkg.TableColumn {
+	c := r.Columns
+	highlightWhen := func(found bool, highlight pkg.Highlight) pkg.Highlight {
+		if found {
+			return highlight
 		}
-
+		return pkg.HighlightGreen
 	}
-
-	// Remove the scorecard columns when that is disable
-	if r.Flags.DisableScorecard {
-		if err := f.SetColVisible(sheetName, "E", false); err != nil {
-			log.Errorf("unable to remove scorecard columns : %s", err)
-		}
-		if err := f.SetColVisible(sheetName, "F", false); err != nil {
-			log.Errorf("unable to remove scorecard columns : %s", err)
-		}
+	return []pkg.TableColumn{
+		{Header: "Package Name", Value: func(i int) interface{} { return c[i].PackageName }},
+		{Header: "Kinds (Suggestion API(s) usage)",
+			Value: func(i int) interface{} { return strings.Join(c[i].KindsDeprecateAPIs, ", ") },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].KindsDeprecateAPIs) > 0 {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			},
+			LinkTo: deprecatedAPIsSheet},
+		{Header: "Is using Webhooks", Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasWebhooks) }},
+		{Header: "Webhook Types", Value: func(i int) interface{} { return strings.Join(c[i].WebhookTypes, ", ") }},
+		{Header: "Webhook Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].WebhookIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].WebhookIssues) > 0 {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Deployment Security Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].SecurityIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].SecurityIssues) > 0 {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Multiple Architectures used", Value: func(i int) interface{} {
+			return strings.Join(pkg.GetUniqueValues(c[i].MultipleArchitectures), ", ")
+		}},
+		{Header: "Has Scorecard Suggestions",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasScorecardSuggestions) },
+			Highlight: func(i int) pkg.Highlight {
+				return highlightWhen(c[i].HasScorecardSuggestions, pkg.HighlightOrange)
+			},
+			LinkTo: scorecardTestsSheet,
+			Hidden: r.Flags.DisableScorecard},
+		{Header: "Has Scorecard Failing Tests",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasScorecardFailingTests) },
+			Highlight: func(i int) pkg.Highlight {
+				return highlightWhen(c[i].HasScorecardFailingTests, pkg.HighlightRed)
+			},
+			LinkTo: scorecardTestsSheet,
+			Hidden: r.Flags.DisableScorecard},
+		{Header: "Has Validator Errors",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasValidatorErrors) },
+			Highlight: func(i int) pkg.Highlight {
+				return highlightWhen(c[i].HasValidatorErrors, pkg.HighlightRed)
+			},
+			LinkTo: validatorFindingsSheet,
+			Hidden: r.Flags.DisableValidators},
+		{Header: "Has Validator Warnings",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasValidatorWarnings) },
+			Highlight: func(i int) pkg.Highlight {
+				return highlightWhen(c[i].HasValidatorWarnings, pkg.HighlightOrange)
+			},
+			LinkTo: validatorFindingsSheet,
+			Hidden: r.Flags.DisableValidators},
+		{Header: "Has Invalid Versioning",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasInvalidVersioning) },
+			Highlight: func(i int) pkg.Highlight {
+				return highlightWhen(c[i].HasInvalidVersioning, pkg.HighlightOrange)
+			}},
+		{Header: "Has Invalid SkipRange",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasInvalidSkipRange) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].HasInvalidSkipRange {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Is multi-channel", Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].IsMultiChannel) }},
+		{Header: "Has Support for All Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].HasSupportForAllNamespaces)
+		}},
+		{Header: "Has Support for Single Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].HasSupportForSingleNamespace)
+		}},
+		{Header: "Has Support for Own Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].HasSupportForOwnNamespaces)
+		}},
+		{Header: "Has Support for Multi Namespaces", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].HasSupportForMultiNamespaces)
+		}},
+		{Header: "Has Infrastructure Support", Value: func(i int) interface{} {
+			return pkg.GetYesOrNo(c[i].HasInfraAnnotation)
+		}},
+		{Header: "Infrastructure Features", Value: func(i int) interface{} {
+			return strings.Join(c[i].InfrastructureFeatures, ", ")
+		}},
+		{Header: "Infrastructure Annotation Issues",
+			Value: func(i int) interface{} { return strings.Join(c[i].InfrastructureIssues, "\n") },
+			Highlight: func(i int) pkg.Highlight {
+				if len(c[i].InfrastructureIssues) > 0 {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Has possible performance issues",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasPossiblePerformIssues) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].HasPossiblePerformIssues {
+					return pkg.HighlightOrange
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Has custom Scorecards",
+			Value: func(i int) interface{} { return pkg.GetYesOrNo(c[i].HasCustomScorecardTests) },
+			Highlight: func(i int) pkg.Highlight {
+				if c[i].HasCustomScorecardTests {
+					return pkg.HighlightGreen
+				}
+				return pkg.NoHighlight
+			}},
+		{Header: "Audit Errors",
+			Value:  func(i int) interface{} { return len(c[i].AuditErrors) },
+			LinkTo: auditErrorsSheet},
 	}
+}
 
-	// Remove the validators columns when that is disable
-	if r.Flags.DisableValidators {
-		if err := f.SetColVisible(sheetName, "G", false); err != nil {
-			log.Errorf("unable to remove validator columns : %s", err)
+func (r *Report) workbookDetails() []pkg.DetailSheet {
+	validators := pkg.DetailSheet{Name: validatorFindingsSheet,
+		Headers: []string{"Package Name", "Level", "Detail"}}
+	scorecard := pkg.DetailSheet{Name: scorecardTestsSheet,
+		Headers: []string{"Package Name", "Type", "Detail"}}
+	auditErrors := pkg.DetailSheet{Name: auditErrorsSheet,
+		Headers: []string{"Package Name", "Error"}}
+	deprecated := pkg.DetailSheet{Name: deprecatedAPIsSheet,
+		Headers: []string{"Package Name", "Kind", "Manifest Name"}}
+
+	for i, v := range r.Columns {
+		for _, e := range pkg.GetUniqueValues(v.ValidatorErrors) {
+			validators.Rows = append(validators.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, "error", e}, Highlight: pkg.HighlightRed})
+		}
+		for _, e := range pkg.GetUniqueValues(v.ValidatorWarnings) {
+			validators.Rows = append(validators.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, "warning", e}, Highlight: pkg.HighlightOrange})
+		}
+		for _, e := range pkg.GetUniqueValues(v.ScorecardFailingTests) {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, "failing test", e}, Highlight: pkg.HighlightOrange})
+		}
+		for _, e := range pkg.GetUniqueValues(v.ScorecardErrors) {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, "error", e}, Highlight: pkg.HighlightRed})
+		}
+		for _, e := range pkg.GetUniqueValues(v.ScorecardSuggestions) {
+			scorecard.Rows = append(scorecard.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, "suggestion", e}})
+		}
+		kinds := make([]string, 0, len(v.DeprecateAPIsManifests))
+		for k := range v.DeprecateAPIsManifests {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		for _, k := range kinds {
+			for _, name := range v.DeprecateAPIsManifests[k] {
+				deprecated.Rows = append(deprecated.Rows, pkg.DetailRow{Owner: i,
+					Values: []interface{}{v.PackageName, k, name}, Highlight: pkg.HighlightOrange})
+			}
 		}
-		if err := f.SetColVisible(sheetName, "H", false); err != nil {
-			log.Errorf("unable to remove validator columns : %s", err)
+		for _, e := range v.AuditErrors {
+			auditErrors.Rows = append(auditErrors.Rows, pkg.DetailRow{Owner: i,
+				Values: []interface{}{v.PackageName, e}})
 		}
 	}
 
-	if err := f.AddTable(sheetName, "A5", "S5", pkg.TableFormat); err != nil {
-		log.Errorf("to set table format : %s", err)
+	details := []pkg.DetailSheet{deprecated, auditErrors}
+	if !r.Flags.DisableScorecard {
+		details = append([]pkg.DetailSheet{scorecard}, details...)
 	}
-
-	reportFilePath := filepath.Join(r.Flags.OutputPath,
-		pkg.GetReportName(r.Flags.IndexImage, "packages", "xlsx"))
-
-	if err := f.SaveAs(reportFilePath); err != nil {
-		return err
+	if !r.Flags.DisableValidators {
+		details = append([]pkg.DetailSheet{validators}, details...)
 	}
-	return nil
+	return details
 }
 
-func (r *Report) writeJSON() error {
-	data, err := json.Marshal(r)
-	if err != nil {
-		return err
-	}
+// Kind returns the type of the report
+func (r *Report) Kind() string {
+	return "packages"
+}
+
+// ImageName returns the index image audited
+func (r *Report) ImageName() string {
+	return r.Flags.IndexImage
+}
 
-	const reportType = "package"
-	return pkg.WriteJSON(data, r.Flags.IndexImage, r.Flags.OutputPath, reportType)
+// OutputPath returns the directory where the report should be written
+func (r *Report) OutputPath() string {
+	return r.Flags.OutputPath
 }
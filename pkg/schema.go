@@ -0,0 +1,121 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is the subset of the JSON Schema (draft-07) used to describe the reports.
+// Note that the Type is a string or a list of them
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+}
+
+// NewJSONSchema returns the schema of the JSON output of the value informed, which is obtained from
+// its type and the json tags of its fields. Note that the fields without omitempty are required
+func NewJSONSchema(title string, v interface{}) *JSONSchema {
+	s := typeSchema(reflect.TypeOf(v), map[reflect.Type]bool{})
+	s.Schema = jsonSchemaDraft
+	s.Title = title
+	return s
+}
+
+// typeSchema returns the schema of the type. The types which are being visited are tracked to not
+// loop in the recursive types
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) *JSONSchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem(), visiting)
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return &JSONSchema{Type: "string", Format: "date-time"}
+		}
+		if visiting[t] {
+			return &JSONSchema{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		s := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+		addFields(s, t, visiting)
+		return s
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string"}
+		}
+		// the nil slices and maps are output as null
+		return &JSONSchema{Type: []string{"array", "null"}, Items: typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return &JSONSchema{Type: []string{"object", "null"}, AdditionalProperties: typeSchema(t.Elem(), visiting)}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// addFields adds the exported fields of the struct as properties of the schema. The fields of the
+// embedded structs without json tags are added as the fields of the struct, as done by encoding/json
+func addFields(s *JSONSchema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (len(field.PkgPath) > 0 && !field.Anonymous) {
+			continue
+		}
+		name, options := field.Name, ""
+		if len(tag) > 0 {
+			parts := strings.SplitN(tag, ",", 2)
+			if len(parts[0]) > 0 {
+				name = parts[0]
+			}
+			if len(parts) > 1 {
+				options = parts[1]
+			}
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && len(tag) == 0 && fieldType.Kind() == reflect.Struct {
+			addFields(s, fieldType, visiting)
+			continue
+		}
+		s.Properties[name] = typeSchema(field.Type, visiting)
+		if !strings.Contains(options, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewJSONSchema(t *testing.T) {
+	type inner struct {
+		Value string `json:"value"`
+	}
+	type embedded struct {
+		Embedded bool `json:"embedded"`
+	}
+	type report struct {
+		embedded
+		Name     string            `json:"name"`
+		Count    int32             `json:"count,omitempty"`
+		Items    []inner           `json:"items,omitempty"`
+		Labels   map[string]string `json:"labels"`
+		Ignored  string            `json:"-"`
+		NoTag    float64
+		internal string
+	}
+
+	want := &JSONSchema{
+		Schema: jsonSchemaDraft,
+		Title:  "Report",
+		Type:   "object",
+		Properties: map[string]*JSONSchema{
+			"embedded": {Type: "boolean"},
+			"name":     {Type: "string"},
+			"count":    {Type: "integer"},
+			"items": {Type: []string{"array", "null"}, Items: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"value": {Type: "string"}},
+				Required:   []string{"value"},
+			}},
+			"labels": {Type: []string{"object", "null"}, AdditionalProperties: &JSONSchema{Type: "string"}},
+			"NoTag":  {Type: "number"},
+		},
+		Required: []string{"embedded", "name", "labels", "NoTag"},
+	}
+	if got := NewJSONSchema("Report", report{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewJSONSchema() got = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+// Diff defines the changes in the bundles of a catalog between two runs
+type Diff struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Added   []string       `json:"added,omitempty"`
+	Removed []string       `json:"removed,omitempty"`
+	Changed []BundleChange `json:"changed,omitempty"`
+}
+
+// BundleChange defines the findings of a bundle which were found or fixed between two runs
+type BundleChange struct {
+	BundleName string   `json:"bundleName"`
+	New        []string `json:"new,omitempty"`
+	Fixed      []string `json:"fixed,omitempty"`
+}
+
+// DiffReports returns the bundles added, removed and the ones which had their findings changed
+func DiffReports(from, to bundles.Report) Diff {
+	var diff Diff
+	before := mapBundles(from)
+	after := mapBundles(to)
+
+	for name, b := range after {
+		old, found := before[name]
+		if !found {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+		newFindings, fixed := compareFindings(findings(old), findings(b))
+		if len(newFindings) > 0 || len(fixed) > 0 {
+			diff.Changed = append(diff.Changed, BundleChange{BundleName: name, New: newFindings, Fixed: fixed})
+		}
+	}
+	for name := range before {
+		if _, found := after[name]; !found {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].BundleName < diff.Changed[j].BundleName
+	})
+	return diff
+}
+
+func mapBundles(report bundles.Report) map[string]bundles.Column {
+	result := make(map[string]bundles.Column)
+	for _, v := range report.Columns {
+		result[v.BundleName] = v
+	}
+	return result
+}
+
+// findings returns the issues found for the bundle prefixed by their source
+func findings(b bundles.Column) []string {
+	var result []string
+	add := func(prefix string, values []string) {
+		for _, v := range values {
+			result = append(result, fmt.Sprintf("%s: %s", prefix, v))
+		}
+	}
+	add("validator error", b.ValidatorErrors)
+	add("validator warning", b.ValidatorWarnings)
+	add("scorecard failing test", b.ScorecardFailingTests)
+	add("scorecard error", b.ScorecardErrors)
+	add("deprecated API", b.KindsDeprecateAPIs)
+	add("audit error", b.AuditErrors)
+	return result
+}
+
+func compareFindings(before, after []string) ([]string, []string) {
+	var newFindings, fixed []string
+	for _, v := range after {
+		if !contains(before, v) {
+			newFindings = append(newFindings, v)
+		}
+	}
+	for _, v := range before {
+		if !contains(after, v) {
+			fixed = append(fixed, v)
+		}
+	}
+	return newFindings, fixed
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+func TestDiffReports(t *testing.T) {
+	from := bundles.Report{Columns: []bundles.Column{
+		{BundleName: "etcd.v0.9.0", ValidatorErrors: []string{"csv.Spec.Icon not specified"}},
+		{BundleName: "etcd.v0.9.2", ValidatorWarnings: []string{"csv.Spec.minKubeVersion is not informed"}},
+		{BundleName: "mongodb.v1.0.0"},
+	}}
+	to := bundles.Report{Columns: []bundles.Column{
+		{BundleName: "etcd.v0.9.2", KindsDeprecateAPIs: []string{"CRD"}},
+		{BundleName: "mongodb.v1.0.0"},
+		{BundleName: "mongodb.v1.1.0"},
+	}}
+
+	want := Diff{
+		Added:   []string{"mongodb.v1.1.0"},
+		Removed: []string{"etcd.v0.9.0"},
+		Changed: []BundleChange{{
+			BundleName: "etcd.v0.9.2",
+			New:        []string{"deprecated API: CRD"},
+			Fixed:      []string{"validator warning: csv.Spec.minKubeVersion is not informed"},
+		}},
+	}
+	if got := DiffReports(from, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffReports() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/audit/pkg/metrics"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/reports/custom"
+	"github.com/operator-framework/audit/pkg/reports/packages"
+	"github.com/operator-framework/audit/pkg/writers"
+)
+
+// CatalogSummary defines the status of a catalog returned by the API
+type CatalogSummary struct {
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	Running   bool      `json:"running"`
+	LatestRun string    `json:"latestRun,omitempty"`
+	Bundles   int       `json:"bundles"`
+	Packages  int       `json:"packages"`
+	Runs      []*Run    `json:"runs,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// PackageDetails defines the data of a package returned by the API
+type PackageDetails struct {
+	Package *packages.Column     `json:"package,omitempty"`
+	Grade   *custom.PackageGrade `json:"grade,omitempty"`
+	Bundles []bundles.Column     `json:"bundles"`
+}
+
+// Handler returns the handler with the following endpoints:
+//
+//	GET  /                                              index page with the catalogs and their dashboards
+//	GET  /api/catalogs                                  list the catalogs
+//	GET  /api/catalogs/{catalog}                        get the catalog and its runs
+//	POST /api/catalogs/{catalog}/runs                   schedule an audit of the catalog
+//	GET  /api/catalogs/{catalog}/packages/{package}     get the package, its grade and bundles
+//	GET  /api/catalogs/{catalog}/bundles/{bundle}       get the bundle findings
+//	GET  /api/catalogs/{catalog}/diff?from=<id>&to=<id> diff two runs (default: the two latest)
+//	GET  /catalogs/{catalog}/{bundles|packages|grade}.html the HTML dashboards
+//	GET  /metrics                                       the Prometheus metrics of the latest runs
+//
+// The query parameter run=<id> can be used to get the data of a specific run instead of the latest one
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/api/catalogs", s.handleCatalogs)
+	mux.HandleFunc("/api/catalogs/", s.handleCatalogAPI)
+	mux.HandleFunc("/catalogs/", s.handleDashboard)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	return mux
+}
+
+func (s *Server) summary(name string, withRuns bool) CatalogSummary {
+	catalog, _ := s.options.Config.Find(name)
+	summary := CatalogSummary{Name: name, Image: catalog.Image, Running: s.store.isRunning(name)}
+	if latest := s.store.latest(name); latest != nil {
+		summary.LatestRun = latest.ID
+		summary.UpdatedAt = latest.StartedAt
+		if !latest.FinishedAt.IsZero() {
+			summary.UpdatedAt = latest.FinishedAt
+		}
+		summary.Bundles = len(latest.Bundles.Columns)
+		if latest.Packages != nil {
+			summary.Packages = len(latest.Packages.Columns)
+		}
+	}
+	if withRuns {
+		summary.Runs = s.store.list(name)
+	}
+	return summary
+}
+
+func (s *Server) handleCatalogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var result []CatalogSummary
+	for _, c := range s.options.Config.Catalogs {
+		result = append(result, s.summary(c.Name, false))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleCatalogAPI handles the endpoints under /api/catalogs/{catalog}
+func (s *Server) handleCatalogAPI(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(strings.TrimPrefix(r.URL.Path, "/api/catalogs/"))
+	if len(parts) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	name := parts[0]
+	if _, found := s.options.Config.Find(name); !found {
+		http.Error(w, "catalog not found", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.summary(name, true))
+	case len(parts) == 2 && parts[1] == "runs" && r.Method == http.MethodPost:
+		if !s.Schedule(name) {
+			http.Error(w, "the catalog audit is already scheduled or running", http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, s.summary(name, false))
+	case len(parts) == 3 && parts[1] == "packages" && r.Method == http.MethodGet:
+		s.handlePackage(w, r, name, parts[2])
+	case len(parts) == 3 && parts[1] == "bundles" && r.Method == http.MethodGet:
+		s.handleBundle(w, r, name, parts[2])
+	case len(parts) == 2 && parts[1] == "diff" && r.Method == http.MethodGet:
+		s.handleDiff(w, r, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// run returns the run informed via the query parameter run or the latest one
+func (s *Server) run(w http.ResponseWriter, r *http.Request, catalog string) *Run {
+	var run *Run
+	if id := r.URL.Query().Get("run"); len(id) > 0 {
+		run = s.store.get(catalog, id)
+	} else {
+		run = s.store.latest(catalog)
+	}
+	if run == nil || run.Bundles == nil {
+		http.Error(w, "no results were found for the catalog", http.StatusNotFound)
+		return nil
+	}
+	return run
+}
+
+func (s *Server) handlePackage(w http.ResponseWriter, r *http.Request, catalog, name string) {
+	run := s.run(w, r, catalog)
+	if run == nil {
+		return
+	}
+	details := PackageDetails{}
+	for _, v := range run.Bundles.Columns {
+		if v.PackageName == name {
+			details.Bundles = append(details.Bundles, v)
+		}
+	}
+	if run.Packages != nil {
+		for i, v := range run.Packages.Columns {
+			if v.PackageName == name {
+				details.Package = &run.Packages.Columns[i]
+				break
+			}
+		}
+	}
+	for i, v := range run.Grade.PackageGrade {
+		if v.PackageName == name {
+			details.Grade = &run.Grade.PackageGrade[i]
+			break
+		}
+	}
+	if len(details.Bundles) == 0 && details.Package == nil {
+		http.Error(w, "package not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) handleBundle(w http.ResponseWriter, r *http.Request, catalog, name string) {
+	run := s.run(w, r, catalog)
+	if run == nil {
+		return
+	}
+	for _, v := range run.Bundles.Columns {
+		if v.BundleName == name {
+			writeJSON(w, http.StatusOK, v)
+			return
+		}
+	}
+	http.Error(w, "bundle not found", http.StatusNotFound)
+}
+
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request, catalog string) {
+	var withResults []*Run
+	for _, v := range s.store.list(catalog) {
+		if v.Bundles != nil {
+			withResults = append(withResults, v)
+		}
+	}
+	if len(withResults) < 2 {
+		http.Error(w, "at least two runs are required to diff", http.StatusNotFound)
+		return
+	}
+
+	from := withResults[len(withResults)-2]
+	to := withResults[len(withResults)-1]
+	if id := r.URL.Query().Get("from"); len(id) > 0 {
+		from = s.store.get(catalog, id)
+	}
+	if id := r.URL.Query().Get("to"); len(id) > 0 {
+		to = s.store.get(catalog, id)
+	}
+	if from == nil || to == nil || from.Bundles == nil || to.Bundles == nil {
+		http.Error(w, "run not found", http.StatusNotFound)
+		return
+	}
+
+	diff := DiffReports(*from.Bundles, *to.Bundles)
+	diff.From, diff.To = from.ID, to.ID
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// handleDashboard renders the dashboards /catalogs/{catalog}/{bundles|packages|grade}.html
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(strings.TrimPrefix(r.URL.Path, "/catalogs/"))
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	if _, found := s.options.Config.Find(parts[0]); !found {
+		http.Error(w, "catalog not found", http.StatusNotFound)
+		return
+	}
+	run := s.run(w, r, parts[0])
+	if run == nil {
+		return
+	}
+
+	var err error
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	switch parts[1] {
+	case "bundles.html":
+		err = writers.HTML(w, run.Bundles.Workbook())
+	case "packages.html":
+		if run.Packages == nil {
+			http.Error(w, "no packages results were found for the catalog", http.StatusNotFound)
+			return
+		}
+		err = writers.HTML(w, run.Packages.Workbook())
+	case "grade.html":
+		var t *template.Template
+		t, err = template.ParseFiles(s.options.GradeTemplate)
+		if err == nil {
+			err = t.Execute(w, run.Grade)
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Errorf("unable to render the dashboard %s : %s", r.URL.Path, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Audit</title></head>
+<body>
+<h1>Audit</h1>
+<table>
+<tr><th>Catalog</th><th>Image</th><th>Latest run</th><th>Bundles</th><th>Packages</th><th>Dashboards</th></tr>
+{{- range . }}
+<tr>
+<td><a href="/api/catalogs/{{ .Name }}">{{ .Name }}</a></td>
+<td>{{ .Image }}</td>
+<td>{{ if .LatestRun }}{{ .LatestRun }}{{ end }}{{ if .Running }} (running){{ end }}</td>
+<td>{{ .Bundles }}</td>
+<td>{{ .Packages }}</td>
+<td>{{ if .LatestRun }}<a href="/catalogs/{{ .Name }}/bundles.html">bundles</a>
+<a href="/catalogs/{{ .Name }}/packages.html">packages</a>
+<a href="/catalogs/{{ .Name }}/grade.html">grade</a>{{ end }}</td>
+</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	var result []CatalogSummary
+	for _, c := range s.options.Config.Catalogs {
+		result = append(result, s.summary(c.Name, false))
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, result); err != nil {
+		log.Errorf("unable to render the index page : %s", err)
+	}
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := metrics.Write(w, s.metrics()); err != nil {
+		log.Errorf("unable to write the metrics : %s", err)
+	}
+}
+
+func splitPath(path string) []string {
+	var parts []string
+	for _, v := range strings.Split(path, "/") {
+		if len(v) > 0 {
+			parts = append(parts, v)
+		}
+	}
+	return parts
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("unable to write the response : %s", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server provides the audit-tool serve mode which audits the catalogs periodically and exposes
+// the latest results via a REST API and the HTML dashboards.
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/catalogs"
+	"github.com/operator-framework/audit/pkg/metrics"
+)
+
+// Options defines the configuration of the server
+type Options struct {
+	Config *catalogs.Config
+	// DataDir is where the reports of each run are stored
+	DataDir string
+	// History is the number of runs kept per catalog
+	History int
+	// Binary is the path of the audit-tool used to generate the reports
+	Binary string
+	// GradeTemplate is the path of the template used to render the grade dashboard
+	GradeTemplate string
+	// MetricsFile is the path where the metrics are written after each run to be used with the
+	// textfile collector of the node exporter (optional)
+	MetricsFile string
+}
+
+// Server audits the catalogs of the config periodically and keeps their latest results
+type Server struct {
+	options  Options
+	interval time.Duration
+	store    *store
+	queue    chan string
+}
+
+// New returns a server for the options informed
+func New(options Options) (*Server, error) {
+	interval, err := options.Config.GetInterval()
+	if err != nil {
+		return nil, err
+	}
+	if options.History < 2 {
+		return nil, fmt.Errorf("the history should keep at least 2 runs to allow diff them")
+	}
+	if err := os.MkdirAll(options.DataDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create the data dir %s : %s", options.DataDir, err)
+	}
+	return &Server{
+		options:  options,
+		interval: interval,
+		store:    newStore(options.DataDir, options.History),
+		queue:    make(chan string, len(options.Config.Catalogs)),
+	}, nil
+}
+
+// Start loads the runs stored in the data dir and schedules the audits until the context is done.
+// The catalogs are audit one at time since the audit-tool uses the tmp and output dirs of the working dir
+func (s *Server) Start(ctx context.Context) {
+	for _, c := range s.options.Config.Catalogs {
+		s.store.load(c.Name)
+		// audit only the catalogs which have not a recent result
+		if latest := s.store.latest(c.Name); latest == nil || time.Since(latest.StartedAt) > s.interval {
+			s.Schedule(c.Name)
+		}
+	}
+
+	if len(s.options.MetricsFile) > 0 {
+		if err := s.writeMetricsFile(); err != nil {
+			log.Errorf("unable to write the metrics file %s : %s", s.options.MetricsFile, err)
+		}
+	}
+
+	go s.worker(ctx)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, c := range s.options.Config.Catalogs {
+					s.Schedule(c.Name)
+				}
+			}
+		}
+	}()
+}
+
+// Schedule adds the catalog to the queue of audits. It returns false when the catalog is already
+// scheduled or running
+func (s *Server) Schedule(name string) bool {
+	if !s.store.trySetRunning(name) {
+		return false
+	}
+	s.queue <- name
+	return true
+}
+
+func (s *Server) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case name := <-s.queue:
+			catalog, found := s.options.Config.Find(name)
+			if found {
+				s.audit(catalog)
+			}
+			s.store.setRunning(name, false)
+		}
+	}
+}
+
+// audit generates the bundles and packages reports of the catalog by using the audit-tool
+func (s *Server) audit(catalog catalogs.Catalog) {
+	log.Infof("Starting audit of the catalog %s", catalog.Name)
+	run := &Run{ID: newRunID(), StartedAt: time.Now().UTC()}
+	dir := s.store.runDir(catalog.Name, run.ID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		log.Errorf("unable to create the dir %s : %s", dir, err)
+		return
+	}
+
+	for _, kind := range []string{"bundles", "packages"} {
+		cmd := exec.Command(s.options.Binary, catalog.Args(kind, catalog.Image, dir, pkg.JSON)...)
+		if _, err := pkg.RunCommand(cmd); err != nil {
+			log.Errorf("unable to audit the %s of the catalog %s : %s", kind, catalog.Name, err)
+			run.Errors = append(run.Errors, fmt.Sprintf("unable to audit the %s : %s", kind, err))
+		}
+	}
+
+	loaded, err := readRun(dir)
+	if err != nil {
+		run.Errors = append(run.Errors, err.Error())
+	} else {
+		run.Bundles, run.Packages, run.Grade = loaded.Bundles, loaded.Packages, loaded.Grade
+	}
+	run.FinishedAt = time.Now().UTC()
+	s.store.add(catalog.Name, run)
+	log.Infof("Audit of the catalog %s completed", catalog.Name)
+
+	if len(s.options.MetricsFile) > 0 {
+		if err := s.writeMetricsFile(); err != nil {
+			log.Errorf("unable to write the metrics file %s : %s", s.options.MetricsFile, err)
+		}
+	}
+}
+
+// metrics returns the metrics of the latest run of each catalog. The bundles data is obtained
+// from the latest run which has the results when the latest one failed
+func (s *Server) metrics() []metrics.Metric {
+	var runs []metrics.Run
+	for _, c := range s.options.Config.Catalogs {
+		list := s.store.list(c.Name)
+		if len(list) == 0 {
+			continue
+		}
+		last := list[len(list)-1]
+		run := metrics.Run{Catalog: c.Name, Bundles: last.Bundles, StartedAt: last.StartedAt, Errors: len(last.Errors)}
+		if !last.FinishedAt.IsZero() {
+			run.Duration = last.FinishedAt.Sub(last.StartedAt)
+		}
+		if run.Bundles == nil {
+			if latest := s.store.latest(c.Name); latest != nil {
+				run.Bundles = latest.Bundles
+			}
+		}
+		runs = append(runs, run)
+	}
+	return metrics.FromRuns(runs)
+}
+
+// writeMetricsFile writes the metrics in a temporary file which is renamed, so that the
+// textfile collector never reads a partial file
+func (s *Server) writeMetricsFile() error {
+	tmp := s.options.MetricsFile + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := metrics.Write(f, s.metrics()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.options.MetricsFile)
+}
+
+// DefaultGradeTemplate returns the path of the grade dashboard template from the working dir
+func DefaultGradeTemplate(currentPath string) string {
+	return filepath.Join(currentPath, "cmd", "custom", "grade", "template.go.tmpl")
+}
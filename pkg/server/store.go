@@ -0,0 +1,246 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+	"github.com/operator-framework/audit/pkg/reports/custom"
+	"github.com/operator-framework/audit/pkg/reports/packages"
+)
+
+const runIDFormat = "20060102T150405Z"
+const runFileName = "run.json"
+
+// Run defines the result of an audit of a catalog. The reports are stored in the run dir as JSON files
+type Run struct {
+	ID         string    `json:"id"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+	Errors     []string  `json:"errors,omitempty"`
+
+	Bundles  *bundles.Report     `json:"-"`
+	Packages *packages.Report    `json:"-"`
+	Grade    *custom.GradeReport `json:"-"`
+}
+
+// store keeps the latest runs per catalog in memory and in the data dir, so that they are kept after a restart
+type store struct {
+	mutex   sync.RWMutex
+	dataDir string
+	history int
+	runs    map[string][]*Run
+	running map[string]bool
+}
+
+func newStore(dataDir string, history int) *store {
+	return &store{
+		dataDir: dataDir,
+		history: history,
+		runs:    make(map[string][]*Run),
+		running: make(map[string]bool),
+	}
+}
+
+func (s *store) runDir(catalog, runID string) string {
+	return filepath.Join(s.dataDir, catalog, runID)
+}
+
+// load reads the runs of the catalog which were stored in the data dir
+func (s *store) load(catalog string) {
+	entries, err := ioutil.ReadDir(filepath.Join(s.dataDir, catalog))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Errorf("unable to read the runs of %s : %s", catalog, err)
+		}
+		return
+	}
+	var runs []*Run
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		run, err := readRun(s.runDir(catalog, e.Name()))
+		if err != nil {
+			log.Errorf("unable to load the run %s of %s : %s", e.Name(), catalog, err)
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.runs[catalog] = runs
+	s.prune(catalog)
+}
+
+// add stores the run as the latest one of the catalog and removes the oldest runs
+func (s *store) add(catalog string, run *Run) {
+	data, err := json.Marshal(run)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(s.runDir(catalog, run.ID), runFileName), data, 0644)
+	}
+	if err != nil {
+		log.Errorf("unable to write the run %s of %s : %s", run.ID, catalog, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.runs[catalog] = append(s.runs[catalog], run)
+	s.prune(catalog)
+}
+
+func (s *store) prune(catalog string) {
+	for len(s.runs[catalog]) > s.history {
+		oldest := s.runs[catalog][0]
+		if err := os.RemoveAll(s.runDir(catalog, oldest.ID)); err != nil {
+			log.Errorf("unable to remove the run %s of %s : %s", oldest.ID, catalog, err)
+		}
+		s.runs[catalog] = s.runs[catalog][1:]
+	}
+}
+
+// list returns the runs of the catalog sorted from the oldest to the latest
+func (s *store) list(catalog string) []*Run {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return append([]*Run{}, s.runs[catalog]...)
+}
+
+// latest returns the latest run of the catalog which has the bundles report
+func (s *store) latest(catalog string) *Run {
+	runs := s.list(catalog)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Bundles != nil {
+			return runs[i]
+		}
+	}
+	return nil
+}
+
+// get returns the run of the catalog with the ID informed
+func (s *store) get(catalog, runID string) *Run {
+	for _, v := range s.list(catalog) {
+		if v.ID == runID {
+			return v
+		}
+	}
+	return nil
+}
+
+// trySetRunning marks the catalog as running and returns false when it was already running
+func (s *store) trySetRunning(catalog string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.running[catalog] {
+		return false
+	}
+	s.running[catalog] = true
+	return true
+}
+
+func (s *store) setRunning(catalog string, running bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.running[catalog] = running
+}
+
+func (s *store) isRunning(catalog string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.running[catalog]
+}
+
+// readRun loads the run and the reports from its dir
+func readRun(dir string) (*Run, error) {
+	run := &Run{ID: filepath.Base(dir)}
+	if data, err := pkg.ReadFile(filepath.Join(dir, runFileName)); err == nil {
+		if err := json.Unmarshal(data, run); err != nil {
+			return nil, err
+		}
+	}
+	// the runs which were not finished have not the run.json
+	if run.StartedAt.IsZero() {
+		run.StartedAt, _ = time.Parse(runIDFormat, run.ID)
+	}
+
+	bundlesFile, err := findReport(dir, "bundles")
+	if err != nil {
+		return nil, err
+	}
+	if len(bundlesFile) > 0 {
+		var report bundles.Report
+		if err := readJSON(bundlesFile, &report); err != nil {
+			return nil, err
+		}
+		if err := report.Migrate(); err != nil {
+			return nil, err
+		}
+		run.Bundles = &report
+		run.Grade = custom.NewGradeReport(report)
+	}
+
+	packagesFile, err := findReport(dir, "packages")
+	if err != nil {
+		return nil, err
+	}
+	if len(packagesFile) > 0 {
+		var report packages.Report
+		if err := readJSON(packagesFile, &report); err != nil {
+			return nil, err
+		}
+		if err := report.Migrate(); err != nil {
+			return nil, err
+		}
+		run.Packages = &report
+	}
+	return run, nil
+}
+
+// findReport returns the path of the JSON report of the kind informed or empty when it is not found
+func findReport(dir, kind string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s_*.json", kind)))
+	if err != nil || len(matches) == 0 {
+		return "", err
+	}
+	return matches[len(matches)-1], nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := pkg.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to parse %s : %s", path, err)
+	}
+	return nil
+}
+
+func newRunID() string {
+	return time.Now().UTC().Format(runIDFormat)
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package site builds a static site to browse the reports found in a directory.
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/catalogs"
+)
+
+// Report is a JSON report found in the reports dir
+type Report struct {
+	Metadata pkg.ReportMetadata
+	// Path of the JSON report
+	Path string
+	// Files are the paths of the report in all formats which were output (e.g. JSON and XLSX)
+	Files []string
+	// Dashboards are the paths of the custom dashboards generated for the image of the report
+	Dashboards []string
+}
+
+// reportContent has the fields of the reports used to find their metadata. Note that the flags are
+// only used for the reports which were generated before the metadata was added to them
+type reportContent struct {
+	Metadata pkg.ReportMetadata
+	Flags    struct {
+		Image      string `json:"image"`
+		IndexImage string `json:"index-image"`
+	}
+	GenerateAt string
+}
+
+// LoadReports returns all reports found in the dir informed and its sub-dirs
+func LoadReports(dir string) ([]Report, error) {
+	var reports []Report
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".json") {
+			return nil
+		}
+		metadata, found := readMetadata(path)
+		if !found || len(metadata.Catalog) == 0 {
+			return nil
+		}
+		report := Report{Metadata: metadata, Path: path}
+
+		// the report is output with the same name in all formats
+		files, err := filepath.Glob(strings.TrimSuffix(path, ".json") + ".*")
+		if err != nil {
+			return err
+		}
+		report.Files = files
+
+		// the custom dashboards are generated from the bundles reports in the dashboards dir
+		if metadata.Kind == "bundles" {
+			pattern := filepath.Join(filepath.Dir(path), "dashboards",
+				"*_"+pkg.ImageFileName(metadata.Image)+"_*.html")
+			if report.Dashboards, err = filepath.Glob(pattern); err != nil {
+				return err
+			}
+		}
+		reports = append(reports, report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Metadata.GeneratedAt != reports[j].Metadata.GeneratedAt {
+			return reports[i].Metadata.GeneratedAt > reports[j].Metadata.GeneratedAt
+		}
+		return reports[i].Metadata.Kind < reports[j].Metadata.Kind
+	})
+	return reports, nil
+}
+
+// readMetadata returns the metadata of the report or false when the file is not a report
+func readMetadata(path string) (pkg.ReportMetadata, bool) {
+	data, err := pkg.ReadFile(path)
+	if err != nil {
+		log.Errorf("unable to read the file %s : %s", path, err)
+		return pkg.ReportMetadata{}, false
+	}
+	var content reportContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		log.Debugf("ignoring the file %s which is not a report : %s", path, err)
+		return pkg.ReportMetadata{}, false
+	}
+	kind, image := content.Metadata.Kind, content.Metadata.Image
+	if len(kind) == 0 {
+		// the reports generated before the metadata was added only have the kind in their names
+		kind = strings.Split(filepath.Base(path), "_")[0]
+		image = content.Flags.Image
+		if len(image) == 0 {
+			image = content.Flags.IndexImage
+		}
+		if len(image) == 0 || !isKind(kind) {
+			return pkg.ReportMetadata{}, false
+		}
+	}
+	if err := content.Metadata.Migrate(kind, image, content.GenerateAt); err != nil {
+		log.Errorf("unable to migrate the report %s : %s", path, err)
+		return pkg.ReportMetadata{}, false
+	}
+	return content.Metadata, true
+}
+
+func isKind(kind string) bool {
+	for _, v := range catalogs.Kinds {
+		if v == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package site
+
+import (
+	// To embed the html templates
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/audit/pkg"
+	"github.com/operator-framework/audit/pkg/reports/bundles"
+)
+
+//go:embed template.go.tmpl
+var siteTemplate string
+
+// link is an anchor of the pages, where Href is relative to the page
+type link struct {
+	Name string
+	Href string
+}
+
+type indexPage struct {
+	Title    string
+	Catalogs []catalogRow
+}
+
+type catalogRow struct {
+	link
+	Tags        []string
+	GeneratedAt string
+}
+
+type catalogPage struct {
+	Title    string
+	Home     string
+	Tags     []tagSection
+	Packages []packageRow
+}
+
+type tagSection struct {
+	Name    string
+	Reports []reportRow
+}
+
+type reportRow struct {
+	Kind        string
+	GeneratedAt string
+	Files       []link
+	Dashboards  []link
+}
+
+type packageRow struct {
+	link
+	Tags []string
+}
+
+type packagePage struct {
+	Title       string
+	Home        string
+	Catalog     string
+	CatalogHref string
+	Tags        []packageTag
+}
+
+type packageTag struct {
+	Name        string
+	GeneratedAt string
+	Report      link
+	Bundles     []bundles.Column
+}
+
+// catalog groups the reports of the same index image repository by its tags
+type catalog struct {
+	name string
+	tags map[string][]Report
+}
+
+// Build generates the static site with the reports found in the reportsDir in the outputPath. The site has
+// an index page with all catalogs, a page per catalog with the reports of each tag and a page per package
+// with the bundles found in the latest bundles report of each tag.
+func Build(reportsDir, outputPath string) error {
+	reports, err := LoadReports(reportsDir)
+	if err != nil {
+		return fmt.Errorf("unable to load the reports from %s : %s", reportsDir, err)
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf("no reports were found in %s", reportsDir)
+	}
+
+	t := template.Must(template.New("site").Parse(siteTemplate))
+	index := indexPage{Title: "Audit Reports"}
+	for _, c := range groupByCatalog(reports) {
+		dir := filepath.Join(outputPath, "catalogs", pkg.ImageFileName(c.name))
+		if err := buildCatalog(t, c, dir, outputPath); err != nil {
+			return err
+		}
+		row := catalogRow{link: link{Name: c.name, Href: relativeLink(outputPath, filepath.Join(dir, "index.html"))},
+			Tags: sortedTags(c)}
+		for _, tag := range row.Tags {
+			if generatedAt := c.tags[tag][0].Metadata.GeneratedAt; generatedAt > row.GeneratedAt {
+				row.GeneratedAt = generatedAt
+			}
+		}
+		index.Catalogs = append(index.Catalogs, row)
+	}
+	return writePage(t, "index", filepath.Join(outputPath, "index.html"), index)
+}
+
+func buildCatalog(t *template.Template, c catalog, dir, outputPath string) error {
+	home := relativeLink(dir, filepath.Join(outputPath, "index.html"))
+	page := catalogPage{Title: c.name, Home: home}
+
+	// packages has the bundles of each package per tag found in the latest bundles reports
+	packages := map[string][]packageTag{}
+	for _, tag := range sortedTags(c) {
+		section := tagSection{Name: tag}
+		var latestBundles *Report
+		for i, r := range c.tags[tag] {
+			row := reportRow{Kind: r.Metadata.Kind, GeneratedAt: r.Metadata.GeneratedAt}
+			for _, f := range r.Files {
+				row.Files = append(row.Files, link{Name: strings.ToUpper(strings.TrimPrefix(filepath.Ext(f), ".")),
+					Href: relativeLink(dir, f)})
+			}
+			for _, f := range r.Dashboards {
+				row.Dashboards = append(row.Dashboards, link{Name: strings.Split(filepath.Base(f), "_")[0],
+					Href: relativeLink(dir, f)})
+			}
+			section.Reports = append(section.Reports, row)
+			if latestBundles == nil && r.Metadata.Kind == "bundles" {
+				latestBundles = &c.tags[tag][i]
+			}
+		}
+		page.Tags = append(page.Tags, section)
+
+		if latestBundles == nil {
+			continue
+		}
+		report, err := readBundlesReport(latestBundles.Path)
+		if err != nil {
+			return err
+		}
+		byPackage := map[string][]bundles.Column{}
+		for _, b := range report.Columns {
+			byPackage[b.PackageName] = append(byPackage[b.PackageName], b)
+		}
+		for name, list := range byPackage {
+			packages[name] = append(packages[name], packageTag{
+				Name:        tag,
+				GeneratedAt: latestBundles.Metadata.GeneratedAt,
+				Report:      link{Name: "JSON", Href: relativeLink(filepath.Join(dir, "packages"), latestBundles.Path)},
+				Bundles:     list,
+			})
+		}
+	}
+
+	var names []string
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, "packages", fmt.Sprintf("%s.html", filepath.Base(name)))
+		row := packageRow{link: link{Name: name, Href: relativeLink(dir, path)}}
+		for _, v := range packages[name] {
+			row.Tags = append(row.Tags, v.Name)
+		}
+		page.Packages = append(page.Packages, row)
+
+		err := writePage(t, "package", path, packagePage{
+			Title:       name,
+			Home:        relativeLink(filepath.Dir(path), filepath.Join(outputPath, "index.html")),
+			Catalog:     c.name,
+			CatalogHref: relativeLink(filepath.Dir(path), filepath.Join(dir, "index.html")),
+			Tags:        packages[name],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return writePage(t, "catalog", filepath.Join(dir, "index.html"), page)
+}
+
+// groupByCatalog returns the catalogs sorted by name. Note that the reports keep the order informed,
+// which is the latest first
+func groupByCatalog(reports []Report) []catalog {
+	byName := map[string]*catalog{}
+	var names []string
+	for _, r := range reports {
+		c, found := byName[r.Metadata.Catalog]
+		if !found {
+			c = &catalog{name: r.Metadata.Catalog, tags: map[string][]Report{}}
+			byName[c.name] = c
+			names = append(names, c.name)
+		}
+		tag := r.Metadata.Tag
+		if len(tag) == 0 {
+			tag = "latest"
+		}
+		c.tags[tag] = append(c.tags[tag], r)
+	}
+	sort.Strings(names)
+	var result []catalog
+	for _, name := range names {
+		result = append(result, *byName[name])
+	}
+	return result
+}
+
+// sortedTags returns the tags of the catalog in descending order, so that the newest versions are shown first
+func sortedTags(c catalog) []string {
+	var tags []string
+	for tag := range c.tags {
+		tags = append(tags, tag)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(tags)))
+	return tags
+}
+
+func readBundlesReport(path string) (bundles.Report, error) {
+	data, err := pkg.ReadFile(path)
+	if err != nil {
+		return bundles.Report{}, err
+	}
+	var report bundles.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return bundles.Report{}, fmt.Errorf("unable to parse the report %s : %s", path, err)
+	}
+	if err := report.Migrate(); err != nil {
+		return bundles.Report{}, fmt.Errorf("unable to migrate the report %s : %s", path, err)
+	}
+	return report, nil
+}
+
+// relativeLink returns the link of the target from a page in the dir informed
+func relativeLink(dir, target string) string {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func writePage(t *template.Template, name, path string, data interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return t.ExecuteTemplate(f, name, data)
+}
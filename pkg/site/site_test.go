@@ -0,0 +1,69 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	output := t.TempDir()
+	if err := Build("../../testdata/reports/operatorhubio_catalog", output); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	catalogDir := filepath.Join("catalogs", "quay.io_operatorhubio_catalog")
+	pages := []struct {
+		path string
+		want []string
+	}{
+		{
+			path: "index.html",
+			want: []string{"quay.io/operatorhubio/catalog", `href="catalogs/quay.io_operatorhubio_catalog/index.html"`},
+		},
+		{
+			path: filepath.Join(catalogDir, "index.html"),
+			want: []string{`href="../../index.html"`, "latest",
+				"bundles_quay.io_operatorhubio_catalog_latest_2021-08-16.json",
+				"bundles_quay.io_operatorhubio_catalog_latest_2021-08-16.xlsx",
+				`href="packages/etcd.html"`},
+		},
+		{
+			path: filepath.Join(catalogDir, "packages", "etcd.html"),
+			want: []string{`href="../index.html"`, "etcdoperator.v0.9.4-clusterwide"},
+		},
+	}
+	for _, p := range pages {
+		data, err := os.ReadFile(filepath.Join(output, p.path))
+		if err != nil {
+			t.Errorf("unable to read the page %s : %v", p.path, err)
+			continue
+		}
+		for _, want := range p.want {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("page %s does not contain %s", p.path, want)
+			}
+		}
+	}
+}
+
+func TestBuildWithoutReports(t *testing.T) {
+	if err := Build(t.TempDir(), t.TempDir()); err == nil {
+		t.Errorf("Build() error = nil, want an error when no reports are found")
+	}
+}
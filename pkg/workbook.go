@@ -0,0 +1,333 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const summarySheetName = "Summary"
+
+// Highlight defines the font colour used to flag a cell of the workbook
+type Highlight int
+
+const (
+	NoHighlight Highlight = iota
+	HighlightGreen
+	HighlightOrange
+	HighlightRed
+)
+
+// SummaryField defines a name and value pair which is written in the summary sheet
+type SummaryField struct {
+	Name  string
+	Value interface{}
+}
+
+// SummaryChart defines a chart which is added to the summary sheet.
+// The Type is one of the chart types supported by excelize (e.g. pie, col, bar)
+type SummaryChart struct {
+	Title  string
+	Type   string
+	Series []SummaryField
+}
+
+// TableColumn defines a column of the main table sheet and how its value is obtained for each row.
+// The Name is the JSON name of the field which is output in the column
+type TableColumn struct {
+	Name      string
+	Header    string
+	Value     func(row int) interface{}
+	Highlight func(row int) Highlight
+	// LinkTo is the name of the detail sheet where the findings of the row can be checked
+	LinkTo string
+	Hidden bool
+}
+
+// DetailSheet defines a normalized sheet which has one finding per row
+type DetailSheet struct {
+	Name    string
+	Headers []string
+	Rows    []DetailRow
+}
+
+// DetailRow defines a finding of a detail sheet. The Owner is the index of the row of the
+// main table which the finding belongs to and it is used to link both sheets. The findings
+// which do not belong to any row have a negative Owner and are not linked
+type DetailRow struct {
+	Owner     int
+	Values    []interface{}
+	Highlight Highlight
+}
+
+// Workbook defines the content of a report in the XLSX format.
+// It is composed by a summary sheet with the metadata, counts and charts, the main table sheet
+// and the detail sheets which are linked with the main table rows
+type Workbook struct {
+	Title     string
+	Metadata  []SummaryField
+	Counts    []SummaryField
+	Charts    []SummaryChart
+	TableName string
+	Columns   []TableColumn
+	Rows      int
+	Details   []DetailSheet
+}
+
+type workbookStyles struct {
+	highlights map[Highlight]int
+	link       int
+	bold       int
+}
+
+// Save writes the workbook in the path informed
+func (w *Workbook) Save(path string) error {
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", summarySheetName)
+
+	styles := newWorkbookStyles(f)
+
+	w.writeSummary(f, styles)
+
+	f.NewSheet(w.TableName)
+	for i, detail := range w.Details {
+		f.NewSheet(detail.Name)
+		w.writeDetail(f, styles, detail, i+2)
+	}
+	w.writeTable(f, styles)
+
+	f.SetActiveSheet(f.GetSheetIndex(summarySheetName))
+	return f.SaveAs(path)
+}
+
+func newWorkbookStyles(f *excelize.File) workbookStyles {
+	styles := workbookStyles{highlights: map[Highlight]int{}}
+	colors := map[Highlight]string{
+		HighlightGreen:  "#3FA91E",
+		HighlightOrange: "#ec8f1c",
+		HighlightRed:    "#EC1C1C",
+	}
+	for k, v := range colors {
+		styles.highlights[k], _ = f.NewStyle(&excelize.Style{Font: &excelize.Font{Color: v}})
+	}
+	styles.link, _ = f.NewStyle(&excelize.Style{Font: &excelize.Font{Color: "#1265BE", Underline: "single"}})
+	styles.bold, _ = f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	return styles
+}
+
+func (w *Workbook) writeSummary(f *excelize.File, styles workbookStyles) {
+	_ = f.SetCellValue(summarySheetName, "A1", w.Title)
+	_ = f.SetCellStyle(summarySheetName, "A1", "A1", styles.bold)
+	_ = f.SetColWidth(summarySheetName, "A", "A", 40)
+	_ = f.SetColWidth(summarySheetName, "B", "B", 60)
+
+	line := 3
+	for _, v := range w.Metadata {
+		_ = f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", line), v.Name)
+		_ = f.SetCellValue(summarySheetName, fmt.Sprintf("B%d", line), v.Value)
+		line++
+	}
+
+	line++
+	_ = f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", line), "Counts")
+	_ = f.SetCellStyle(summarySheetName, fmt.Sprintf("A%d", line), fmt.Sprintf("A%d", line), styles.bold)
+	line++
+	for _, v := range w.Counts {
+		_ = f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", line), v.Name)
+		_ = f.SetCellValue(summarySheetName, fmt.Sprintf("B%d", line), v.Value)
+		line++
+	}
+
+	// The charts data are written after the counts since the charts require cell ranges
+	for i, chart := range w.Charts {
+		line++
+		_ = f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", line), chart.Title)
+		_ = f.SetCellStyle(summarySheetName, fmt.Sprintf("A%d", line), fmt.Sprintf("A%d", line), styles.bold)
+		line++
+		start := line
+		for _, v := range chart.Series {
+			_ = f.SetCellValue(summarySheetName, fmt.Sprintf("A%d", line), v.Name)
+			_ = f.SetCellValue(summarySheetName, fmt.Sprintf("B%d", line), v.Value)
+			line++
+		}
+		if len(chart.Series) == 0 {
+			continue
+		}
+
+		format, err := json.Marshal(map[string]interface{}{
+			"type": chart.Type,
+			"series": []map[string]string{{
+				"name":       fmt.Sprintf("%s!$A$%d", summarySheetName, start-1),
+				"categories": fmt.Sprintf("%s!$A$%d:$A$%d", summarySheetName, start, line-1),
+				"values":     fmt.Sprintf("%s!$B$%d:$B$%d", summarySheetName, start, line-1),
+			}},
+			"title":     map[string]string{"name": chart.Title},
+			"dimension": map[string]int{"width": 480, "height": 290},
+		})
+		if err != nil {
+			log.Errorf("unable to create the chart format : %s", err)
+			continue
+		}
+		if err := f.AddChart(summarySheetName, fmt.Sprintf("D%d", 3+i*16), string(format)); err != nil {
+			log.Errorf("unable to add chart %s : %s", chart.Title, err)
+		}
+	}
+}
+
+func (w *Workbook) writeTable(f *excelize.File, styles workbookStyles) {
+	sheet := w.TableName
+
+	// firstDetailRow stores per detail sheet the first line found for each row of the table
+	firstDetailRow := make(map[string]map[int]int)
+	for _, detail := range w.Details {
+		firstDetailRow[detail.Name] = make(map[int]int)
+		for k, v := range detail.Rows {
+			if _, found := firstDetailRow[detail.Name][v.Owner]; !found {
+				firstDetailRow[detail.Name][v.Owner] = k + 2
+			}
+		}
+	}
+
+	for c, column := range w.Columns {
+		header, _ := excelize.CoordinatesToCellName(c+1, 1)
+		_ = f.SetCellValue(sheet, header, column.Header)
+
+		colName, _ := excelize.ColumnNumberToName(c + 1)
+		_ = f.SetColWidth(sheet, colName, colName, 20)
+
+		for row := 0; row < w.Rows; row++ {
+			cell, _ := excelize.CoordinatesToCellName(c+1, row+2)
+			if err := f.SetCellValue(sheet, cell, column.Value(row)); err != nil {
+				log.Errorf("to add %s cell value : %s", column.Header, err)
+			}
+
+			if len(column.LinkTo) > 0 {
+				if line, found := firstDetailRow[column.LinkTo][row]; found {
+					link := fmt.Sprintf("'%s'!A%d", column.LinkTo, line)
+					if err := f.SetCellHyperLink(sheet, cell, link, "Location"); err != nil {
+						log.Errorf("unable to link %s with %s : %s", cell, link, err)
+					}
+					_ = f.SetCellStyle(sheet, cell, cell, styles.link)
+				}
+			}
+
+			if column.Highlight != nil {
+				if style, found := styles.highlights[column.Highlight(row)]; found {
+					_ = f.SetCellStyle(sheet, cell, cell, style)
+				}
+			}
+		}
+
+		if column.Hidden {
+			if err := f.SetColVisible(sheet, colName, false); err != nil {
+				log.Errorf("unable to hide the column %s : %s", column.Header, err)
+			}
+		}
+	}
+
+	if len(w.Columns) > 0 {
+		last, _ := excelize.CoordinatesToCellName(len(w.Columns), w.Rows+1)
+		if err := f.AddTable(sheet, "A1", last, tableFormat("table1")); err != nil {
+			log.Errorf("unable to add table format : %s", err)
+		}
+		_ = f.SetPanes(sheet, `{"freeze":true,"split":false,"x_split":1,"y_split":1,`+
+			`"top_left_cell":"B2","active_pane":"bottomRight"}`)
+	}
+}
+
+func (w *Workbook) writeDetail(f *excelize.File, styles workbookStyles, detail DetailSheet, tableID int) {
+	sheet := detail.Name
+	for c, v := range detail.Headers {
+		cell, _ := excelize.CoordinatesToCellName(c+1, 1)
+		_ = f.SetCellValue(sheet, cell, v)
+		colName, _ := excelize.ColumnNumberToName(c + 1)
+		_ = f.SetColWidth(sheet, colName, colName, 30)
+	}
+
+	for k, row := range detail.Rows {
+		line := k + 2
+		for c, v := range row.Values {
+			cell, _ := excelize.CoordinatesToCellName(c+1, line)
+			if err := f.SetCellValue(sheet, cell, v); err != nil {
+				log.Errorf("to add %s cell value : %s", detail.Headers[c], err)
+			}
+			if style, found := styles.highlights[row.Highlight]; found {
+				_ = f.SetCellStyle(sheet, cell, cell, style)
+			}
+		}
+
+		// link the finding back to its row in the main table
+		if row.Owner < 0 {
+			continue
+		}
+		cell := fmt.Sprintf("A%d", line)
+		link := fmt.Sprintf("'%s'!A%d", w.TableName, row.Owner+2)
+		if err := f.SetCellHyperLink(sheet, cell, link, "Location"); err != nil {
+			log.Errorf("unable to link %s with %s : %s", cell, link, err)
+		}
+		_ = f.SetCellStyle(sheet, cell, cell, styles.link)
+	}
+
+	if len(detail.Rows) > 0 {
+		last, _ := excelize.CoordinatesToCellName(len(detail.Headers), len(detail.Rows)+1)
+		if err := f.AddTable(sheet, "A1", last, tableFormat(fmt.Sprintf("table%d", tableID))); err != nil {
+			log.Errorf("unable to add table format : %s", err)
+		}
+	}
+}
+
+// CountRowsWith returns how many rows of the main table match the condition informed
+func CountRowsWith(rows int, condition func(row int) bool) int {
+	count := 0
+	for i := 0; i < rows; i++ {
+		if condition(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// SelectColumns returns the columns which have the names informed in the same order.
+// All columns are returned when no name is informed
+func SelectColumns(columns []TableColumn, names []string) ([]TableColumn, error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+	var result []TableColumn
+	for _, name := range names {
+		found := false
+		for _, c := range columns {
+			if c.Name == name {
+				result = append(result, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			var valid []string
+			for _, c := range columns {
+				valid = append(valid, c.Name)
+			}
+			return nil, fmt.Errorf("%s is not a valid column. The available options are: %s",
+				name, strings.Join(valid, ", "))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+)
+
+func TestWorkbookSave(t *testing.T) {
+	values := []string{"etcd", "memcached"}
+	w := &Workbook{
+		Title:     "Audit Test Report",
+		Metadata:  []SummaryField{{Name: "Image used", Value: "quay.io/example/index:v1"}},
+		Counts:    []SummaryField{{Name: "Packages", Value: len(values)}},
+		TableName: "Packages",
+		Columns: []TableColumn{
+			{Name: "packageName", Header: "Package Name", Value: func(i int) interface{} { return values[i] }},
+			{Name: "errors", Header: "Audit Errors", Value: func(i int) interface{} { return i },
+				LinkTo: "Audit Errors"},
+			{Name: "hidden", Header: "Hidden", Value: func(i int) interface{} { return "" }, Hidden: true},
+		},
+		Rows: len(values),
+		Details: []DetailSheet{{Name: "Audit Errors", Headers: []string{"Package Name", "Error"},
+			Rows: []DetailRow{
+				{Owner: 1, Values: []interface{}{"memcached", "unable to pull the image"}},
+				{Owner: -1, Values: []interface{}{"removed", "the bundle is not in the report"}},
+			}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := w.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("unable to open the workbook : %v", err)
+	}
+
+	cells := []struct {
+		sheet, cell, want string
+	}{
+		{summarySheetName, "A1", "Audit Test Report"},
+		{summarySheetName, "A3", "Image used"},
+		{summarySheetName, "B3", "quay.io/example/index:v1"},
+		{summarySheetName, "A6", "Packages"},
+		{summarySheetName, "B6", "2"},
+		{"Packages", "A1", "Package Name"},
+		{"Packages", "A3", "memcached"},
+		{"Audit Errors", "B2", "unable to pull the image"},
+	}
+	for _, c := range cells {
+		if got, _ := f.GetCellValue(c.sheet, c.cell); got != c.want {
+			t.Errorf("cell %s!%s = %q, want %q", c.sheet, c.cell, got, c.want)
+		}
+	}
+
+	links := []struct {
+		sheet, cell, want string
+	}{
+		{"Packages", "B3", "'Audit Errors'!A2"},
+		{"Audit Errors", "A2", "'Packages'!A3"},
+	}
+	for _, l := range links {
+		if found, got, _ := f.GetCellHyperLink(l.sheet, l.cell); !found || got != l.want {
+			t.Errorf("link of %s!%s = %q, want %q", l.sheet, l.cell, got, l.want)
+		}
+	}
+	if found, _, _ := f.GetCellHyperLink("Packages", "B2"); found {
+		t.Errorf("link of Packages!B2 found, want no link for the rows without findings")
+	}
+	if found, _, _ := f.GetCellHyperLink("Audit Errors", "A3"); found {
+		t.Errorf("link of 'Audit Errors'!A3 found, want no link for the findings without row")
+	}
+	if visible, _ := f.GetColVisible("Packages", "C"); visible {
+		t.Errorf("column Packages!C is visible, want hidden")
+	}
+}
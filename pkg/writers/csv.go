@@ -0,0 +1,42 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"encoding/csv"
+	"os"
+)
+
+// csvWriter outputs the main table of the report. Note that the CSV format has no
+// support for many sheets, so the detail sheets are not output
+type csvWriter struct{}
+
+func (csvWriter) Write(report Report) error {
+	f, err := os.Create(reportFilePath(report, "csv"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t := mainTable(report.Workbook())
+	w := csv.NewWriter(f)
+	if err := w.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	// To embed the html template
+	_ "embed"
+	"html/template"
+	"io"
+	"os"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+//go:embed template.go.tmpl
+var htmlTemplate string
+
+// htmlWriter outputs the report as a standalone HTML page which can be served statically
+type htmlWriter struct{}
+
+type htmlPage struct {
+	Title    string
+	Metadata []pkg.SummaryField
+	Counts   []pkg.SummaryField
+	Tables   []table
+}
+
+func (htmlWriter) Write(report Report) error {
+	f, err := os.Create(reportFilePath(report, "html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return HTML(f, report.Workbook())
+}
+
+// HTML writes the workbook content as a standalone HTML page
+func HTML(w io.Writer, wb *pkg.Workbook) error {
+	page := htmlPage{
+		Title:    wb.Title,
+		Metadata: wb.Metadata,
+		Counts:   wb.Counts,
+		Tables:   append([]table{mainTable(wb)}, detailTables(wb)...),
+	}
+	t := template.Must(template.New("report").Parse(htmlTemplate))
+	return t.Execute(w, page)
+}
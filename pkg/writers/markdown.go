@@ -0,0 +1,77 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+// markdownWriter outputs the report as markdown tables which can be pasted in PRs and issues
+type markdownWriter struct{}
+
+func (markdownWriter) Write(report Report) error {
+	return ioutil.WriteFile(reportFilePath(report, "md"), []byte(Markdown(report.Workbook())), 0644)
+}
+
+// Markdown returns the workbook content as markdown
+func Markdown(wb *pkg.Workbook) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n", escapeMarkdown(wb.Title)))
+	for _, v := range wb.Metadata {
+		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", v.Name, escapeMarkdown(fmt.Sprintf("%v", v.Value))))
+	}
+	sb.WriteString("\n## Counts\n\n")
+	for _, v := range wb.Counts {
+		sb.WriteString(fmt.Sprintf("- **%s**: %v\n", v.Name, v.Value))
+	}
+
+	tables := append([]table{mainTable(wb)}, detailTables(wb)...)
+	for _, t := range tables {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", t.Name))
+		writeMarkdownTable(&sb, t)
+	}
+	return sb.String()
+}
+
+func writeMarkdownTable(sb *strings.Builder, t table) {
+	var separator []string
+	for range t.Headers {
+		separator = append(separator, "---")
+	}
+	writeMarkdownRow(sb, t.Headers)
+	writeMarkdownRow(sb, separator)
+	for _, row := range t.Rows {
+		writeMarkdownRow(sb, row)
+	}
+}
+
+func writeMarkdownRow(sb *strings.Builder, values []string) {
+	var escaped []string
+	for _, v := range values {
+		escaped = append(escaped, escapeMarkdown(v))
+	}
+	sb.WriteString(fmt.Sprintf("| %s |\n", strings.Join(escaped, " | ")))
+}
+
+// escapeMarkdown ensures that the value will not break the table
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r", "")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package writers provides the implementations used to output the reports in each format supported.
+package writers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/operator-framework/audit/pkg"
+)
+
+// Report defines what the reports need to provide in order to be output by the writers
+type Report interface {
+	// Kind returns the type of the report (e.g. bundles) which is used to name the files
+	Kind() string
+	// ImageName returns the index image audited which is used to name the files
+	ImageName() string
+	// OutputPath returns the directory where the files should be written
+	OutputPath() string
+	// Workbook returns the tabular representation of the report
+	Workbook() *pkg.Workbook
+}
+
+// Writer outputs a report in a specific format
+type Writer interface {
+	Write(report Report) error
+}
+
+var writers = map[string]Writer{
+	pkg.JSON:     jsonWriter{},
+	pkg.Xls:      xlsWriter{},
+	pkg.CSV:      csvWriter{},
+	pkg.Markdown: markdownWriter{},
+	pkg.HTML:     htmlWriter{},
+}
+
+// Formats returns the formats which can be informed via the --output flag
+func Formats() []string {
+	return []string{pkg.JSON, pkg.Xls, pkg.CSV, pkg.Markdown, pkg.HTML, pkg.All}
+}
+
+// ParseFormats returns the formats from a comma-separated list such as json,csv,md.
+// Note that the format all is kept as an alias for json,xls
+func ParseFormats(formats string) ([]string, error) {
+	var result []string
+	for _, v := range strings.Split(formats, ",") {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		if v == pkg.All {
+			result = append(result, pkg.JSON, pkg.Xls)
+			continue
+		}
+		if _, found := writers[v]; !found {
+			return nil, fmt.Errorf("invalid output format : %s. The available options are: %s",
+				v, strings.Join(Formats(), ", "))
+		}
+		result = append(result, v)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no output format informed. The available options are: %s",
+			strings.Join(Formats(), ", "))
+	}
+	return pkg.GetUniqueValues(result), nil
+}
+
+// Output writes the report in all formats informed
+func Output(report Report, formats string) error {
+	list, err := ParseFormats(formats)
+	if err != nil {
+		return err
+	}
+	for _, format := range list {
+		if err := writers[format].Write(report); err != nil {
+			return fmt.Errorf("unable to output the report in the %s format : %s", format, err)
+		}
+	}
+	return nil
+}
+
+type jsonWriter struct{}
+
+func (jsonWriter) Write(report Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return pkg.WriteJSON(data, report.ImageName(), report.OutputPath(), report.Kind())
+}
+
+type xlsWriter struct{}
+
+func (xlsWriter) Write(report Report) error {
+	return report.Workbook().Save(reportFilePath(report, "xlsx"))
+}
+
+func reportFilePath(report Report, extension string) string {
+	return filepath.Join(report.OutputPath(), pkg.GetReportName(report.ImageName(), report.Kind(), extension))
+}
+
+// table is the plain text representation of a sheet of the workbook
+type table struct {
+	Name    string
+	Headers []string
+	Rows    [][]string
+}
+
+// mainTable returns the main table of the workbook without the columns which are hidden
+func mainTable(wb *pkg.Workbook) table {
+	t := table{Name: wb.TableName}
+	var columns []pkg.TableColumn
+	for _, c := range wb.Columns {
+		if !c.Hidden {
+			columns = append(columns, c)
+			t.Headers = append(t.Headers, c.Header)
+		}
+	}
+	for i := 0; i < wb.Rows; i++ {
+		var row []string
+		for _, c := range columns {
+			row = append(row, fmt.Sprintf("%v", c.Value(i)))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// detailTables returns the detail sheets of the workbook which have findings
+func detailTables(wb *pkg.Workbook) []table {
+	var result []table
+	for _, d := range wb.Details {
+		if len(d.Rows) == 0 {
+			continue
+		}
+		t := table{Name: d.Name, Headers: d.Headers}
+		for _, r := range d.Rows {
+			var row []string
+			for _, v := range r.Values {
+				row = append(row, fmt.Sprintf("%v", v))
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		result = append(result, t)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 The Audit Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "should return a single format",
+			formats: "json",
+			want:    []string{"json"},
+		},
+		{
+			name:    "should return the formats from a comma-separated list",
+			formats: "json, csv,md",
+			want:    []string{"json", "csv", "md"},
+		},
+		{
+			name:    "should keep all as an alias for json and xls",
+			formats: "all,json",
+			want:    []string{"json", "xls"},
+		},
+		{
+			name:    "should fail for an invalid format",
+			formats: "json,pdf",
+			wantErr: true,
+		},
+		{
+			name:    "should fail when no format is informed",
+			formats: " , ",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormats(tt.formats)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFormats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFormats() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	got := escapeMarkdown("a|b\r\nc")
+	want := "a\\|b<br>c"
+	if got != want {
+		t.Errorf("escapeMarkdown() got = %v, want %v", got, want)
+	}
+}